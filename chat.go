@@ -0,0 +1,215 @@
+package timetracker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	log "github.com/tommzn/go-log"
+)
+
+// ChatPlatform is a chat service a summary of a report can be posted to.
+type ChatPlatform string
+
+const (
+
+	// CHAT_SLACK posts messages to Slack incoming webhooks.
+	CHAT_SLACK ChatPlatform = "slack"
+
+	// CHAT_TEAMS posts adaptive cards to Microsoft Teams incoming webhooks.
+	CHAT_TEAMS ChatPlatform = "teams"
+)
+
+// NewChatPublisher returns a publisher which posts a summary of a report to an incoming webhook of given chat platform.
+func NewChatPublisher(platform ChatPlatform, webhookUrl string, logger log.Logger) *ChatPublisher {
+	return &ChatPublisher{
+		platform:     platform,
+		webhook:      NewWebhookPublisher(webhookUrl, logger),
+		reportFormat: newReportFormat(logger),
+	}
+}
+
+// ChatPublisher posts a summary of a monthly report, with totals, overtime, absences and compliance warnings,
+// to Slack or Microsoft Teams. Report content itself is not sent, but a summary can contain a link to a report.
+type ChatPublisher struct {
+
+	// ReportFormat contains labels in language of current report.
+	reportFormat
+
+	// Platform defines the format of posted messages.
+	platform ChatPlatform
+
+	// Webhook is used to post messages, including retries.
+	webhook *WebhookPublisher
+
+	// Report is used to create a summary of reports sent with Send.
+	report *MonthlyReport
+
+	// LinkProvider returns a link to a published report. No link is added if it's nil.
+	linkProvider ReportLinkProvider
+}
+
+// WithMonthlyReport assigns the report a summary is created for by Send. It's kept until another report is assigned,
+// so a publisher must not be shared by concurrent sends of different reports. Use SendMonthlyReport instead.
+func (publisher *ChatPublisher) WithMonthlyReport(report *MonthlyReport) {
+	publisher.report = report
+}
+
+// WithReportLink adds a link to a report to summary messages, e.g. a link to a report uploaded by S3Publisher.
+// A report has to be uploaded before, in a FanOutPublisher add a chat publisher with WithTargetAfter its S3 target.
+func (publisher *ChatPublisher) WithReportLink(linkProvider ReportLinkProvider) {
+	publisher.linkProvider = linkProvider
+}
+
+// WithRetryPolicy defines how failed posts are retried.
+func (publisher *ChatPublisher) WithRetryPolicy(retryPolicy RetryPolicy) {
+	publisher.webhook.WithRetryPolicy(retryPolicy)
+}
+
+// Send posts a summary of the report assigned by WithMonthlyReport. Given content is not sent,
+// file name is used to get a link to a report.
+func (publisher *ChatPublisher) Send(content []byte, fileName string) error {
+	return publisher.SendMonthlyReport(content, fileName, publisher.report)
+}
+
+// SendMonthlyReport posts a summary of given monthly report. A passed report is not stored,
+// so a publisher can be used by concurrent sends of different reports.
+func (publisher *ChatPublisher) SendMonthlyReport(content []byte, fileName string, report *MonthlyReport) error {
+
+	if report == nil {
+		return errors.New("Missing report to create a chat summary")
+	}
+	// Locale of a report is applied to a copy of current format, which is shared by all sends.
+	format := publisher.reportFormat
+	format.applyLocale(report)
+
+	message := chatMessageOf(&format, report)
+	if publisher.linkProvider != nil {
+		link, err := publisher.linkProvider.ReportLink(fileName)
+		if err != nil {
+			return err
+		}
+		message.link = link
+	}
+
+	var payload any
+	switch publisher.platform {
+	case CHAT_SLACK:
+		payload = slackPayloadOf(message)
+	case CHAT_TEAMS:
+		payload = teamsPayloadOf(message)
+	default:
+		return fmt.Errorf("Invalid chat platform: %s", publisher.platform)
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return publisher.webhook.Send(body, "summary.json")
+}
+
+// ChatMessage contains all values of a summary, independent of a chat platform.
+type chatMessage struct {
+	title     string
+	facts     [][2]string
+	warning   string
+	link      string
+	linkTitle string
+}
+
+// ChatMessageOf creates a summary of given report with labels and dates of given format.
+func chatMessageOf(format *reportFormat, report *MonthlyReport) chatMessage {
+
+	summary := format.summarize(report)
+	message := chatMessage{
+		title: format.reportTitle(report),
+		facts: [][2]string{
+			{format.message(msgTotalWorkingTime), formatDuration(summary.TotalWorkingTime)},
+			{format.message(msgTargetWorkingTime), formatDuration(summary.TargetWorkingTime)},
+			{format.message(msgOvertime), formatDuration(summary.Overtime)},
+		},
+		linkTitle: format.message(msgOpenReport),
+	}
+
+	absenceTypes := []string{}
+	for recordType := range summary.AbsenceDays {
+		absenceTypes = append(absenceTypes, string(recordType))
+	}
+	sort.Strings(absenceTypes)
+	absences := []string{}
+	for _, recordType := range absenceTypes {
+		absences = append(absences, fmt.Sprintf("%s: %d", format.message(messageKey(recordType)), summary.AbsenceDays[RecordType(recordType)]))
+	}
+	if len(absences) > 0 {
+		message.facts = append(message.facts, [2]string{format.message(msgAbsenceDays), strings.Join(absences, ", ")})
+	}
+	message.facts = append(message.facts, [2]string{format.message(msgCompliance), format.complianceComment(summary)})
+
+	if !summary.IsCompliant() {
+		violations := []string{}
+		for _, violation := range summary.Violations {
+			violations = append(violations, format.formatDate(violation.Date)+": "+violation.Description)
+		}
+		message.warning = strings.Join(violations, "\n")
+	}
+	return message
+}
+
+// SlackPayloadOf returns a Slack message with blocks for title, facts, warnings and a link to a report.
+// Text is used as fallback, e.g. for notifications.
+func slackPayloadOf(message chatMessage) map[string]any {
+
+	fields := []map[string]any{}
+	for _, fact := range message.facts {
+		fields = append(fields, map[string]any{"type": "mrkdwn", "text": fmt.Sprintf("*%s*\n%s", slackEscape(fact[0]), slackEscape(fact[1]))})
+	}
+	blocks := []map[string]any{
+		{"type": "header", "text": map[string]any{"type": "plain_text", "text": message.title}},
+		{"type": "section", "fields": fields},
+	}
+	if message.warning != "" {
+		blocks = append(blocks, map[string]any{"type": "section", "text": map[string]any{"type": "mrkdwn", "text": ":warning: " + slackEscape(message.warning)}})
+	}
+	if message.link != "" {
+		blocks = append(blocks, map[string]any{"type": "section", "text": map[string]any{"type": "mrkdwn", "text": fmt.Sprintf("<%s|%s>", message.link, slackEscape(message.linkTitle))}})
+	}
+	return map[string]any{"text": message.title, "blocks": blocks}
+}
+
+// TeamsPayloadOf returns a Microsoft Teams message with an adaptive card for title, facts, warnings and a link to a report.
+func teamsPayloadOf(message chatMessage) map[string]any {
+
+	facts := []map[string]any{}
+	for _, fact := range message.facts {
+		facts = append(facts, map[string]any{"title": fact[0], "value": fact[1]})
+	}
+	body := []map[string]any{
+		{"type": "TextBlock", "text": message.title, "weight": "Bolder", "size": "Medium", "wrap": true},
+		{"type": "FactSet", "facts": facts},
+	}
+	if message.warning != "" {
+		body = append(body, map[string]any{"type": "TextBlock", "text": message.warning, "color": "Attention", "wrap": true})
+	}
+	card := map[string]any{
+		"type":    "AdaptiveCard",
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"version": "1.4",
+		"body":    body,
+	}
+	if message.link != "" {
+		card["actions"] = []map[string]any{{"type": "Action.OpenUrl", "title": message.linkTitle, "url": message.link}}
+	}
+	return map[string]any{
+		"type": "message",
+		"attachments": []map[string]any{
+			{"contentType": "application/vnd.microsoft.card.adaptive", "content": card},
+		},
+	}
+}
+
+// SlackEscape escapes control characters of Slack messages: &, < and >.
+func slackEscape(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
@@ -0,0 +1,102 @@
+package timetracker
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ChatPublisherTestSuite struct {
+	suite.Suite
+}
+
+func TestChatPublisherTestSuite(t *testing.T) {
+	suite.Run(t, new(ChatPublisherTestSuite))
+}
+
+func (suite *ChatPublisherTestSuite) TestSlackMessage() {
+
+	var payload map[string]any
+	server := suite.webhookServerForTest(&payload)
+	defer server.Close()
+
+	publisher := NewChatPublisher(CHAT_SLACK, server.URL, loggerForTest())
+	publisher.WithMonthlyReport(monthlyReportWithAllTypesForTest())
+	publisher.WithReportLink(&reportLinkForTest{url: "https://example.com/report_202201.xlsx"})
+	suite.Nil(publisher.Send([]byte("report"), "report_202201.xlsx"))
+
+	suite.Equal("Arbeitszeitnachweis 2022-01", payload["text"])
+	blocks := payload["blocks"].([]any)
+	suite.Equal("header", blocks[0].(map[string]any)["type"])
+	fields := blocks[1].(map[string]any)["fields"].([]any)
+	suite.Equal("*Gesamtarbeitszeit*\n17:00", fields[0].(map[string]any)["text"])
+	suite.Contains(fields[3].(map[string]any)["text"], "Urlaub: 2")
+	link := blocks[len(blocks)-1].(map[string]any)["text"].(map[string]any)["text"]
+	suite.Equal("<https://example.com/report_202201.xlsx|Bericht öffnen>", link)
+}
+
+func (suite *ChatPublisherTestSuite) TestTeamsMessage() {
+
+	var payload map[string]any
+	server := suite.webhookServerForTest(&payload)
+	defer server.Close()
+
+	publisher := NewChatPublisher(CHAT_TEAMS, server.URL, loggerForTest())
+	publisher.WithMonthlyReport(monthlyReportWithAllTypesForTest())
+	suite.Nil(publisher.Send([]byte("report"), "report_202201.xlsx"))
+
+	suite.Equal("message", payload["type"])
+	attachment := payload["attachments"].([]any)[0].(map[string]any)
+	suite.Equal("application/vnd.microsoft.card.adaptive", attachment["contentType"])
+	card := attachment["content"].(map[string]any)
+	suite.Equal("AdaptiveCard", card["type"])
+	suite.Nil(card["actions"])
+	facts := card["body"].([]any)[1].(map[string]any)["facts"].([]any)
+	suite.Equal("Gesamtarbeitszeit", facts[0].(map[string]any)["title"])
+	suite.Equal("17:00", facts[0].(map[string]any)["value"])
+}
+
+func (suite *ChatPublisherTestSuite) TestSendErrors() {
+
+	var payload map[string]any
+	server := suite.webhookServerForTest(&payload)
+	defer server.Close()
+
+	publisher := NewChatPublisher(CHAT_SLACK, server.URL, loggerForTest())
+	suite.NotNil(publisher.Send([]byte("report"), "report_202201.xlsx"))
+
+	publisher.WithMonthlyReport(monthlyReportForTest())
+	publisher.WithReportLink(&reportLinkForTest{err: errors.New("No link")})
+	suite.NotNil(publisher.Send([]byte("report"), "report_202201.xlsx"))
+
+	publisher = NewChatPublisher(ChatPlatform("irc"), server.URL, loggerForTest())
+	publisher.WithMonthlyReport(monthlyReportForTest())
+	suite.NotNil(publisher.Send([]byte("report"), "report_202201.xlsx"))
+	suite.Nil(payload)
+}
+
+func (suite *ChatPublisherTestSuite) TestSlackEscape() {
+	suite.Equal("a &amp; b &lt;c&gt;", slackEscape("a & b <c>"))
+}
+
+func (suite *ChatPublisherTestSuite) webhookServerForTest(payload *map[string]any) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		suite.Nil(json.Unmarshal(body, payload))
+		suite.Equal("application/json", r.Header.Get("Content-Type"))
+	}))
+}
+
+type reportLinkForTest struct {
+	url string
+	err error
+}
+
+func (provider *reportLinkForTest) ReportLink(fileName string) (string, error) {
+	return provider.url, provider.err
+}
@@ -0,0 +1,90 @@
+package timetracker
+
+import (
+	"fmt"
+	"time"
+)
+
+// ComplianceRule is a rule working time has to comply with.
+type ComplianceRule string
+
+const (
+
+	// MAX_DAILY_WORKING_TIME is violated if working time of a day exceeds ten hours.
+	MAX_DAILY_WORKING_TIME ComplianceRule = "max_daily_working_time"
+
+	// MIN_REST_PERIOD is violated if there're less than eleven hours between end of work and start of work at next day.
+	MIN_REST_PERIOD ComplianceRule = "min_rest_period"
+
+	// WORK_ON_SUNDAY is violated if there's working time on a Sunday.
+	WORK_ON_SUNDAY ComplianceRule = "work_on_sunday"
+)
+
+// ComplianceViolation is a single violation of a compliance rule.
+type ComplianceViolation struct {
+
+	// Date a violation has occurred.
+	Date
+
+	// Rule which has been violated.
+	Rule ComplianceRule
+
+	// Description contains details about a violation.
+	Description string
+}
+
+// MaxDailyWorkingTime is the maximum working time of a single day.
+const maxDailyWorkingTime = 10 * time.Hour
+
+// MinRestPeriod is the minimum time between end of work and start of work at next day.
+const minRestPeriod = 11 * time.Hour
+
+// CheckCompliance validates all days of given report against working time compliance rules.
+func CheckCompliance(report *MonthlyReport) []ComplianceViolation {
+
+	violations := []ComplianceViolation{}
+	var previousDay *Day
+	for idx, day := range report.Days {
+
+		if day.WorkingTime > maxDailyWorkingTime {
+			violations = append(violations, ComplianceViolation{
+				Date:        day.Date,
+				Rule:        MAX_DAILY_WORKING_TIME,
+				Description: fmt.Sprintf("Working time of %s exceeds %s", formatDuration(day.WorkingTime), formatDuration(maxDailyWorkingTime)),
+			})
+		}
+
+		if day.WorkingTime > 0 && day.Date.AsTime().Weekday() == time.Sunday {
+			violations = append(violations, ComplianceViolation{
+				Date:        day.Date,
+				Rule:        WORK_ON_SUNDAY,
+				Description: fmt.Sprintf("Working time of %s on a Sunday", formatDuration(day.WorkingTime)),
+			})
+		}
+
+		if restPeriod, ok := restPeriodBetween(previousDay, day); ok && restPeriod < minRestPeriod {
+			violations = append(violations, ComplianceViolation{
+				Date:        day.Date,
+				Rule:        MIN_REST_PERIOD,
+				Description: fmt.Sprintf("Rest period of %s is less than %s", formatDuration(restPeriod), formatDuration(minRestPeriod)),
+			})
+		}
+		previousDay = &report.Days[idx]
+	}
+	return violations
+}
+
+// RestPeriodBetween returns time between last working event of previous day and first working event of given day.
+// Returns false if days are not consecutive or one of them has no working events.
+func restPeriodBetween(previousDay *Day, day Day) (time.Duration, bool) {
+
+	if previousDay == nil || !nextDay(previousDay.Date.AsTime()).Equal(day.Date.AsTime()) {
+		return 0, false
+	}
+	previousEvents := workingEvents(previousDay.Events)
+	events := workingEvents(day.Events)
+	if len(previousEvents) == 0 || len(events) == 0 {
+		return 0, false
+	}
+	return events[0].Timestamp.Sub(previousEvents[len(previousEvents)-1].Timestamp), true
+}
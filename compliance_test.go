@@ -0,0 +1,76 @@
+package timetracker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ComplianceTestSuite struct {
+	suite.Suite
+}
+
+func TestComplianceTestSuite(t *testing.T) {
+	suite.Run(t, new(ComplianceTestSuite))
+}
+
+func (suite *ComplianceTestSuite) TestCheckCompliance() {
+
+	records := []TimeTrackingRecord{
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-01T08:00:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-01T16:00:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-02T07:00:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-02T19:00:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-03T05:00:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-03T08:00:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-06T10:00:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-06T12:00:00")},
+	}
+	report, err := NewReportCalulator(records, localeForTest()).MonthlyReport(2022, 2, WORKDAY)
+	suite.Nil(err)
+
+	violations := CheckCompliance(report)
+	suite.Len(violations, 3)
+	suite.Equal(MAX_DAILY_WORKING_TIME, violations[0].Rule)
+	suite.Equal(Date{Year: 2022, Month: 2, Day: 2}, violations[0].Date)
+	suite.Equal(MIN_REST_PERIOD, violations[1].Rule)
+	suite.Equal(Date{Year: 2022, Month: 2, Day: 3}, violations[1].Date)
+	suite.Equal(WORK_ON_SUNDAY, violations[2].Rule)
+	suite.Equal(Date{Year: 2022, Month: 2, Day: 6}, violations[2].Date)
+}
+
+func (suite *ComplianceTestSuite) TestSummarizeMonthlyReport() {
+
+	records := []TimeTrackingRecord{
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-01T08:00:00"), WorkLocation: HOME},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-01T16:00:00"), WorkLocation: HOME},
+		TimeTrackingRecord{Type: VACATION, Timestamp: asTime("2022-02-02T08:00:00")},
+		TimeTrackingRecord{Type: BUSINESS_TRIP, Timestamp: asTime("2022-02-07T08:00:00")},
+		TimeTrackingRecord{Type: BUSINESS_TRIP, Timestamp: asTime("2022-02-07T16:00:00")},
+		TimeTrackingRecord{Type: ILLNESS, Timestamp: asTime("2022-02-08T08:00:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-10T08:00:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-10T16:00:00")},
+	}
+	locale := localeForTest()
+	locale.WorkSchedule = workScheduleForTest()
+	report, err := NewReportCalulator(records, locale).MonthlyReport(2022, 2, WORKDAY)
+	suite.Nil(err)
+
+	summary := SummarizeMonthlyReport(report)
+	suite.Equal(2022, summary.Year)
+	suite.Equal(2, summary.Month)
+	suite.Equal(map[RecordType]int{VACATION: 3, ILLNESS: 2}, summary.AbsenceDays)
+	suite.Equal(5, summary.TotalAbsenceDays())
+	suite.Equal(map[WorkLocation]int{HOME: 1}, summary.WorkLocationDays)
+	suite.Equal(22*time.Hour+30*time.Minute, summary.TotalWorkingTime)
+	suite.Equal(-90*time.Minute, summary.Overtime)
+	suite.True(summary.IsCompliant())
+
+	summary = SummarizeMonthlyReportWithHolidays(report, []Holiday{
+		Holiday{Date: Date{Year: 2022, Month: 2, Day: 3}, Description: "Holiday"},
+		Holiday{Date: Date{Year: 2022, Month: 2, Day: 10}, Description: "Holiday"},
+	})
+	suite.Equal(map[RecordType]int{VACATION: 2, ILLNESS: 2}, summary.AbsenceDays)
+	suite.Equal(4, summary.TotalAbsenceDays())
+}
@@ -0,0 +1,134 @@
+package timetracker
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/tommzn/go-log"
+)
+
+// DurationFormat defines how durations are written to a report.
+type DurationFormat string
+
+const (
+
+	// DURATION_CLOCK writes durations in format HH:MM, e.g. 07:30.
+	DURATION_CLOCK DurationFormat = "clock"
+
+	// DURATION_DECIMAL writes durations as decimal hours, e.g. 7.50.
+	DURATION_DECIMAL DurationFormat = "decimal"
+
+	// DURATION_MINUTES writes durations as number of minutes, e.g. 450.
+	DURATION_MINUTES DurationFormat = "minutes"
+)
+
+// NewCSVReportFormatter returns a new formatter to generate a CSV file for a report.
+// Default delimiter is a comma and durations are written in format HH:MM.
+func NewCSVReportFormatter(logger log.Logger) *CSVReportFormatter {
+	return &CSVReportFormatter{
+		reportFormat:     newReportFormat(logger),
+		delimiter:        ',',
+		durationFormat:   DURATION_CLOCK,
+		decimalSeparator: ".",
+	}
+}
+
+// CSVReportFormatter will generate CSV files for reports.
+type CSVReportFormatter struct {
+
+	// ReportFormat contains date/time formats, timezone and holidays.
+	reportFormat
+
+	// Delimiter is used to separate columns.
+	delimiter rune
+
+	// DurationFormat defines how working and break time should be written.
+	durationFormat DurationFormat
+
+	// DecimalSeparator is used for decimal durations.
+	decimalSeparator string
+}
+
+// WithDelimiter sets the delimiter used to separate columns, e.g. a semicolon.
+func (formatter *CSVReportFormatter) WithDelimiter(delimiter rune) {
+	formatter.delimiter = delimiter
+}
+
+// WithDurationFormat defines how working and break time should be written.
+func (formatter *CSVReportFormatter) WithDurationFormat(durationFormat DurationFormat) {
+	formatter.durationFormat = durationFormat
+}
+
+// WithDecimalSeparator sets the separator used for decimal durations, e.g. a comma.
+func (formatter *CSVReportFormatter) WithDecimalSeparator(decimalSeparator string) {
+	formatter.decimalSeparator = decimalSeparator
+}
+
+// FileExtension returns file extension for CSV files: csv.
+func (formatter *CSVReportFormatter) FileExtension() string {
+	return ".csv"
+}
+
+// WriteMonthlyReportToFile will generate a report outout an writes it to given file.
+func (formatter *CSVReportFormatter) WriteMonthlyReportToFile(report *MonthlyReport, filename string) error {
+
+	buf, err := formatter.WriteMonthlyReportToBuffer(report)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, buf.Bytes(), 0644)
+}
+
+// WriteMonthlyReportToBuffer returns a buffer for gemerated report output.
+func (formatter *CSVReportFormatter) WriteMonthlyReportToBuffer(report *MonthlyReport) (*bytes.Buffer, error) {
+
+	formatter.applyLocale(report)
+
+	buf := new(bytes.Buffer)
+	writer := csv.NewWriter(buf)
+	writer.Comma = formatter.delimiter
+
+	rows := [][]string{formatter.tableHeaders()}
+	forEachDayOfMonth(report, func(day Day) {
+		rows = append(rows, formatter.rowForDay(day))
+	})
+	rows = append(rows, []string{formatter.message(msgTotal), "", "", formatter.formatDuration(report.TotalWorkingTime), "", ""})
+	for _, location := range formatter.workLocationDaysOf(report.WorkLocationDays) {
+		rows = append(rows, []string{location.label, strconv.Itoa(location.days), "", "", "", ""})
+	}
+
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// RowForDay returns all column values for a single day.
+func (formatter *CSVReportFormatter) rowForDay(day Day) []string {
+
+	row := []string{formatter.formatDate(day.Date), "", "", formatter.formatDuration(day.WorkingTime), formatter.formatDuration(day.BreakTime), formatter.commentOf(day)}
+	if len(day.Events) > 0 {
+		row[1] = formatter.formatTime(day.Events[0].Timestamp)
+	}
+	if len(day.Events) > 1 {
+		row[2] = formatter.formatTime(day.Events[len(day.Events)-1].Timestamp)
+	}
+	return row
+}
+
+// FormatDuration writes given duration in defined duration format.
+func (formatter *CSVReportFormatter) formatDuration(d time.Duration) string {
+	switch formatter.durationFormat {
+	case DURATION_DECIMAL:
+		return strings.Replace(fmt.Sprintf("%.2f", d.Round(time.Minute).Hours()), ".", formatter.decimalSeparator, 1)
+	case DURATION_MINUTES:
+		return fmt.Sprintf("%d", int64(d.Round(time.Minute).Minutes()))
+	default:
+		return formatDuration(d)
+	}
+}
@@ -0,0 +1,239 @@
+package timetracker
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CSVMapping defines columns and formats of a CSV export of time entries.
+// Columns are identified by their header, case insensitive.
+type CSVMapping struct {
+
+	// Delimiter is used to separate columns, default is a comma.
+	Delimiter rune
+
+	// StartDate is the header of the column with the start date of an entry.
+	// If there's no start time column, start and end date columns have to contain date and time.
+	StartDate string
+
+	// StartTime is the header of the column with the start time of an entry. Optional.
+	StartTime string
+
+	// EndDate is the header of the column with the end date of an entry. If there's a start time column,
+	// end date is optional and start date is used instead. An entry ending before its start ends at next day in this case.
+	EndDate string
+
+	// EndTime is the header of the column with the end time of an entry. Required if there's a start time column.
+	EndTime string
+
+	// Project is the header of the column with the project of an entry. Optional.
+	Project string
+
+	// DateFormats is a list of layouts, see time package, used to parse dates. First matching layout is used.
+	// Layouts have to contain date and time if there're no time columns.
+	DateFormats []string
+
+	// TimeFormats is a list of layouts, see time package, used to parse times of a day.
+	TimeFormats []string
+}
+
+// NewCSVRecordParser returns a parser for CSV exports with given mapping.
+func NewCSVRecordParser(mapping CSVMapping) *CSVRecordParser {
+	if mapping.Delimiter == 0 {
+		mapping.Delimiter = ','
+	}
+	return &CSVRecordParser{mapping: mapping}
+}
+
+// NewTogglParser returns a parser for detailed CSV exports of Toggl Track.
+func NewTogglParser() *CSVRecordParser {
+	return NewCSVRecordParser(CSVMapping{
+		StartDate:   "Start date",
+		StartTime:   "Start time",
+		EndDate:     "End date",
+		EndTime:     "End time",
+		Project:     "Project",
+		DateFormats: []string{"2006-01-02"},
+		TimeFormats: []string{"15:04:05", "15:04"},
+	})
+}
+
+// NewClockifyParser returns a parser for detailed CSV reports of Clockify.
+// Dates in US format, MM/DD/YYYY, are used first, times can be written in 24 hour or 12 hour format.
+func NewClockifyParser() *CSVRecordParser {
+	return NewCSVRecordParser(CSVMapping{
+		StartDate:   "Start Date",
+		StartTime:   "Start Time",
+		EndDate:     "End Date",
+		EndTime:     "End Time",
+		Project:     "Project",
+		DateFormats: []string{"01/02/2006", "2006-01-02", "02.01.2006"},
+		TimeFormats: []string{"15:04:05", "03:04:05 PM", "15:04", "03:04 PM"},
+	})
+}
+
+// CSVRecordParser reads time entries from CSV exports and converts them into workday start and end records.
+// Overlapping and adjacent entries are merged, so there's a single pair of records for continuous work.
+// Project of a merged entry is the project of its first entry.
+type CSVRecordParser struct {
+	mapping CSVMapping
+}
+
+// CSVTimeEntry is a single time entry of a CSV export.
+type csvTimeEntry struct {
+	start, end time.Time
+	project    string
+}
+
+// Parse returns workday start and end records for all time entries in given CSV export.
+func (parser *CSVRecordParser) Parse(reader io.Reader, location *time.Location) ([]TimeTrackingRecord, error) {
+
+	csvReader := csv.NewReader(reader)
+	csvReader.Comma = parser.mapping.Delimiter
+	csvReader.FieldsPerRecord = -1
+	rows, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return []TimeTrackingRecord{}, nil
+	}
+
+	columns := parser.columnsOf(rows[0])
+	endColumn := parser.mapping.EndDate
+	if parser.withTimeColumns() {
+		endColumn = parser.mapping.EndTime
+	}
+	if columns[parser.mapping.StartDate] == -1 || columns[endColumn] == -1 {
+		return nil, errors.New("Missing start or end column")
+	}
+
+	entries := []csvTimeEntry{}
+	for idx, row := range rows[1:] {
+		if isEmptyRow(row) {
+			continue
+		}
+		entry, err := parser.entryOf(row, columns, location)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid entry in line %d: %s", idx+2, err)
+		}
+		entries = append(entries, entry)
+	}
+	return recordsOfEntries(entries), nil
+}
+
+// ColumnsOf returns indexes of all mapped columns, -1 for missing columns.
+func (parser *CSVRecordParser) columnsOf(headers []string) map[string]int {
+
+	columns := make(map[string]int)
+	for _, column := range []string{parser.mapping.StartDate, parser.mapping.StartTime, parser.mapping.EndDate, parser.mapping.EndTime, parser.mapping.Project} {
+		columns[column] = -1
+		if column == "" {
+			continue
+		}
+		for idx, header := range headers {
+			// Some tools write an UTF-8 byte order mark at the beginning of a file.
+			if strings.EqualFold(strings.TrimSpace(strings.TrimPrefix(header, "\ufeff")), column) {
+				columns[column] = idx
+				break
+			}
+		}
+	}
+	return columns
+}
+
+// EntryOf returns a time entry for given row.
+func (parser *CSVRecordParser) entryOf(row []string, columns map[string]int, location *time.Location) (csvTimeEntry, error) {
+
+	entry := csvTimeEntry{project: strings.TrimSpace(cellValue(row, columns[parser.mapping.Project]))}
+	withTime := parser.withTimeColumns()
+	start, err := parser.timestampOf(cellValue(row, columns[parser.mapping.StartDate]), cellValue(row, columns[parser.mapping.StartTime]), withTime, location)
+	if err != nil {
+		return entry, err
+	}
+
+	endDate := cellValue(row, columns[parser.mapping.EndDate])
+	if withTime && parser.mapping.EndDate == "" {
+		endDate = cellValue(row, columns[parser.mapping.StartDate])
+	}
+	end, err := parser.timestampOf(endDate, cellValue(row, columns[parser.mapping.EndTime]), withTime, location)
+	if err != nil {
+		return entry, err
+	}
+	if withTime && parser.mapping.EndDate == "" && end.Before(start) {
+		end = end.AddDate(0, 0, 1)
+	}
+	if !end.After(start) {
+		return entry, errors.New("End before start")
+	}
+	entry.start, entry.end = start.UTC(), end.UTC()
+	return entry, nil
+}
+
+// WithTimeColumns returns true if date and time of day are in separate columns.
+func (parser *CSVRecordParser) withTimeColumns() bool {
+	return parser.mapping.StartTime != ""
+}
+
+// TimestampOf parses given date and time of day in passed location. Date value has to contain
+// date and time if there's no separate time value.
+func (parser *CSVRecordParser) timestampOf(dateValue, timeValue string, withTime bool, location *time.Location) (time.Time, error) {
+
+	for _, dateFormat := range parser.mapping.DateFormats {
+		date, err := time.ParseInLocation(dateFormat, strings.TrimSpace(dateValue), location)
+		if err != nil {
+			continue
+		}
+		if !withTime {
+			return date, nil
+		}
+		for _, timeFormat := range parser.mapping.TimeFormats {
+			if timeOfDay, err := time.Parse(timeFormat, strings.TrimSpace(timeValue)); err == nil {
+				return time.Date(date.Year(), date.Month(), date.Day(), timeOfDay.Hour(), timeOfDay.Minute(), timeOfDay.Second(), 0, location), nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("Unable to parse time %s", timeValue)
+	}
+	return time.Time{}, fmt.Errorf("Unable to parse date %s", dateValue)
+}
+
+// RecordsOfEntries returns a workday start and end record for each time entry. Overlapping and adjacent
+// entries, e.g. a switch to another project, are merged to avoid duplicate records at same point in time.
+func recordsOfEntries(entries []csvTimeEntry) []TimeTrackingRecord {
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].start.Before(entries[j].start) })
+	mergedEntries := []csvTimeEntry{}
+	for _, entry := range entries {
+		lastIdx := len(mergedEntries) - 1
+		if lastIdx >= 0 && !entry.start.Truncate(time.Minute).After(mergedEntries[lastIdx].end.Truncate(time.Minute)) {
+			if entry.end.After(mergedEntries[lastIdx].end) {
+				mergedEntries[lastIdx].end = entry.end
+			}
+			continue
+		}
+		mergedEntries = append(mergedEntries, entry)
+	}
+
+	records := []TimeTrackingRecord{}
+	for _, entry := range mergedEntries {
+		records = append(records,
+			TimeTrackingRecord{Type: WORKDAY, Timestamp: entry.start, Project: entry.project},
+			TimeTrackingRecord{Type: WORKDAY, Timestamp: entry.end, Project: entry.project})
+	}
+	return records
+}
+
+// IsEmptyRow returns true if all columns of given row are empty.
+func isEmptyRow(row []string) bool {
+	for _, value := range row {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,104 @@
+package timetracker
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CSVRecordParserTestSuite struct {
+	suite.Suite
+	location *time.Location
+}
+
+func TestCSVRecordParserTestSuite(t *testing.T) {
+	suite.Run(t, new(CSVRecordParserTestSuite))
+}
+
+func (suite *CSVRecordParserTestSuite) SetupTest() {
+	location, err := time.LoadLocation("Europe/Berlin")
+	suite.Nil(err)
+	suite.location = location
+}
+
+func (suite *CSVRecordParserTestSuite) TestParseTogglExport() {
+
+	export := "\ufeffUser,Email,Client,Project,Task,Description,Billable,Start date,Start time,End date,End time,Duration,Tags,Amount ()\n" +
+		"Jane,jane@example.com,ACME,Website,,Layout,Yes,2022-03-01,08:30:00,2022-03-01,12:00:00,03:30:00,,\n" +
+		"Jane,jane@example.com,ACME,Backend,,API,Yes,2022-03-01,12:00:00,2022-03-01,13:15:00,01:15:00,,\n" +
+		"Jane,jane@example.com,ACME,Website,,Review,Yes,2022-03-01,14:00:00,2022-03-01,17:00:00,03:00:00,,\n"
+
+	records, err := NewTogglParser().Parse(strings.NewReader(export), suite.location)
+	suite.Nil(err)
+	suite.Len(records, 4)
+	suite.Equal(TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-03-01T07:30:00"), Project: "Website"}, records[0])
+	suite.Equal(TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-03-01T12:15:00"), Project: "Website"}, records[1])
+	suite.Equal(asTime("2022-03-01T13:00:00"), records[2].Timestamp)
+	suite.Equal(asTime("2022-03-01T16:00:00"), records[3].Timestamp)
+}
+
+func (suite *CSVRecordParserTestSuite) TestParseClockifyExport() {
+
+	export := `"Project","Client","Description","Task","User","Group","Email","Tags","Billable","Start Date","Start Time","End Date","End Time","Duration (h)","Duration (decimal)"` + "\n" +
+		`"Website","ACME","Layout","","Jane","","jane@example.com","","Yes","03/01/2022","08:30:00 AM","03/01/2022","04:45:00 PM","08:15:00","8.25"` + "\n" +
+		`"Website","ACME","Deployment","","Jane","","jane@example.com","","Yes","03/02/2022","10:00:00 PM","03/03/2022","01:00:00 AM","03:00:00","3.00"` + "\n"
+
+	records, err := NewClockifyParser().Parse(strings.NewReader(export), suite.location)
+	suite.Nil(err)
+	suite.Len(records, 4)
+	suite.Equal(asTime("2022-03-01T07:30:00"), records[0].Timestamp)
+	suite.Equal(asTime("2022-03-01T15:45:00"), records[1].Timestamp)
+	suite.Equal(asTime("2022-03-02T21:00:00"), records[2].Timestamp)
+	suite.Equal(asTime("2022-03-03T00:00:00"), records[3].Timestamp)
+}
+
+func (suite *CSVRecordParserTestSuite) TestParseGenericExport() {
+
+	parser := NewCSVRecordParser(CSVMapping{
+		Delimiter:   ';',
+		StartDate:   "Datum",
+		StartTime:   "Von",
+		EndTime:     "Bis",
+		DateFormats: []string{"02.01.2006"},
+		TimeFormats: []string{"15:04"},
+	})
+	export := "Datum;Von;Bis;Bemerkung\n01.03.2022;09:00;17:30;\n\n02.03.2022;22:00;02:00;Nachtschicht\n"
+
+	records, err := parser.Parse(strings.NewReader(export), time.UTC)
+	suite.Nil(err)
+	suite.Len(records, 4)
+	suite.Equal(asTime("2022-03-01T09:00:00"), records[0].Timestamp)
+	suite.Equal(asTime("2022-03-01T17:30:00"), records[1].Timestamp)
+	suite.Equal(asTime("2022-03-02T22:00:00"), records[2].Timestamp)
+	suite.Equal(asTime("2022-03-03T02:00:00"), records[3].Timestamp)
+	suite.Equal("", records[0].Project)
+
+	parser = NewCSVRecordParser(CSVMapping{
+		StartDate:   "Begin",
+		EndDate:     "Finish",
+		DateFormats: []string{"2006-01-02T15:04:05"},
+	})
+	records, err = parser.Parse(strings.NewReader("Begin,Finish\n2022-03-01T09:00:00,2022-03-01T12:30:00\n"), time.UTC)
+	suite.Nil(err)
+	suite.Len(records, 2)
+	suite.Equal(asTime("2022-03-01T12:30:00"), records[1].Timestamp)
+}
+
+func (suite *CSVRecordParserTestSuite) TestInvalidExports() {
+
+	_, err := NewTogglParser().Parse(strings.NewReader("User,Start date,Start time\nJane,2022-03-01,08:00:00\n"), time.UTC)
+	suite.NotNil(err)
+
+	_, err = NewTogglParser().Parse(strings.NewReader("Start date,Start time,End date,End time\n2022-03-01,08:00:00,2022-03-01,xx\n"), time.UTC)
+	suite.NotNil(err)
+	suite.Contains(err.Error(), "line 2")
+
+	_, err = NewTogglParser().Parse(strings.NewReader("Start date,Start time,End date,End time\n2022-03-01,08:00:00,2022-03-01,07:00:00\n"), time.UTC)
+	suite.NotNil(err)
+
+	records, err := NewTogglParser().Parse(strings.NewReader(""), time.UTC)
+	suite.Nil(err)
+	suite.Len(records, 0)
+}
@@ -0,0 +1,69 @@
+package timetracker
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CSVReportFormatterTestSuite struct {
+	suite.Suite
+}
+
+func TestCSVReportFormatterTestSuite(t *testing.T) {
+	suite.Run(t, new(CSVReportFormatterTestSuite))
+}
+
+func (suite *CSVReportFormatterTestSuite) TestGenerateReport() {
+
+	formatter := NewCSVReportFormatter(loggerForTest())
+	formatter.WithHolidays([]Holiday{Holiday{Date: Date{Year: 2022, Month: 1, Day: 6}, Description: "Heilige Drei Könige"}})
+	report := monthlyReportWithAllTypesForTest()
+
+	buf, err := formatter.WriteMonthlyReportToBuffer(report)
+	suite.Nil(err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	suite.Len(lines, 33)
+	suite.Equal("Datum,Beginn,Ende,Arbeitszeit,Pause,Bemerkung", lines[0])
+	suite.Equal("01.01.2022,09:00,17:30,08:00,00:30,", lines[1])
+	suite.Equal("06.01.2022,,,00:00,00:00,Heilige Drei Könige", lines[6])
+	suite.Equal("10.01.2022,,,08:00,00:30,Krankheit", lines[10])
+	suite.Equal("Summe,,,17:00,,", lines[32])
+
+	filename := suite.T().TempDir() + "/report" + formatter.FileExtension()
+	suite.Nil(formatter.WriteMonthlyReportToFile(report, filename))
+	_, err = os.Stat(filename)
+	suite.Nil(err)
+}
+
+func (suite *CSVReportFormatterTestSuite) TestWorkLocationDays() {
+
+	report := monthlyReportForTest()
+	report.WorkLocationDays = map[WorkLocation]int{OFFICE: 1, HOME: 3, WorkLocation("boat"): 1, REMOTE: 0}
+	buf, err := NewCSVReportFormatter(loggerForTest()).WriteMonthlyReportToBuffer(report)
+	suite.Nil(err)
+	suite.True(strings.HasSuffix(buf.String(), "Homeoffice (Tage),3,,,,\nBüro (Tage),1,,,,\nboat (Tage),1,,,,\n"), buf.String())
+}
+
+func (suite *CSVReportFormatterTestSuite) TestDurationFormats() {
+
+	formatter := NewCSVReportFormatter(loggerForTest())
+	formatter.WithDelimiter(';')
+	formatter.WithDurationFormat(DURATION_DECIMAL)
+	formatter.WithDecimalSeparator(",")
+	report := monthlyReportWithAllTypesForTest()
+
+	buf, err := formatter.WriteMonthlyReportToBuffer(report)
+	suite.Nil(err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	suite.Equal("01.01.2022;09:00;17:30;8,00;0,50;", lines[1])
+	suite.Equal("Summe;;;17,00;;", lines[32])
+
+	formatter.WithDurationFormat(DURATION_MINUTES)
+	buf, err = formatter.WriteMonthlyReportToBuffer(report)
+	suite.Nil(err)
+	lines = strings.Split(strings.TrimSpace(buf.String()), "\n")
+	suite.Equal("01.01.2022;09:00;17:30;480;30;", lines[1])
+}
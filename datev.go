@@ -0,0 +1,167 @@
+package timetracker
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	log "github.com/tommzn/go-log"
+)
+
+// PayrollValue is a key figure of a monthly report which can be exported to a payroll system.
+type PayrollValue string
+
+const (
+
+	// PAYROLL_WORKING_TIME is the total working time of a month, in hours.
+	PAYROLL_WORKING_TIME PayrollValue = "working_time"
+
+	// PAYROLL_OVERTIME is the overtime of a month, in hours. Negative overtime is exported with a minus sign, e.g. -2,00.
+	PAYROLL_OVERTIME PayrollValue = "overtime"
+
+	// PAYROLL_ILLNESS is the number of days of illness, Monday to Friday.
+	PAYROLL_ILLNESS PayrollValue = "illness"
+
+	// PAYROLL_VACATION is the number of vacation days, Monday to Friday.
+	PAYROLL_VACATION PayrollValue = "vacation"
+)
+
+// NewDATEVReportFormatter returns a new formatter to generate an ASCII file with monthly totals for DATEV Lohn und Gehalt.
+func NewDATEVReportFormatter(logger log.Logger) *DATEVReportFormatter {
+	return &DATEVReportFormatter{
+		reportFormat:     newReportFormat(logger),
+		personnelNumbers: make(map[string]string),
+		wageTypes:        make(map[PayrollValue]string),
+	}
+}
+
+// DATEVReportFormatter generates ASCII files with monthly totals for DATEV Lohn und Gehalt.
+// Files use a custom, simplified layout, not the standard ASCII import format of DATEV Lohn und Gehalt or LODAS,
+// so an import has to be configured for this layout in DATEV.
+// First line contains consultant number, client number and accounting period. It's followed by
+// a line for each payroll value with a wage type: personnel number, accounting period, wage type,
+// hours and days. Values are separated by a semicolon and decimal hours use a comma.
+type DATEVReportFormatter struct {
+
+	// ReportFormat contains date/time formats, timezone and holidays.
+	reportFormat
+
+	// ConsultantNumber identifies the tax consultant in DATEV.
+	consultantNumber string
+
+	// ClientNumber identifies the company of an employee in DATEV.
+	clientNumber string
+
+	// PersonnelNumbers maps device ids to personnel numbers. An empty device id is used as default.
+	personnelNumbers map[string]string
+
+	// WageTypes maps exported values to wage types. Values without a wage type are not exported.
+	wageTypes map[PayrollValue]string
+}
+
+// WithClient sets consultant and client number written to the header of an import file.
+func (formatter *DATEVReportFormatter) WithClient(consultantNumber, clientNumber string) {
+	formatter.consultantNumber = consultantNumber
+	formatter.clientNumber = clientNumber
+}
+
+// WithDefaultPersonnelNumber sets the personnel number used for all devices without an own personnel number.
+func (formatter *DATEVReportFormatter) WithDefaultPersonnelNumber(personnelNumber string) {
+	formatter.personnelNumbers[""] = personnelNumber
+}
+
+// WithPersonnelNumber assigns a personnel number to given device.
+func (formatter *DATEVReportFormatter) WithPersonnelNumber(deviceId, personnelNumber string) {
+	formatter.personnelNumbers[deviceId] = personnelNumber
+}
+
+// WithWageType defines the wage type a payroll value is exported with, e.g. "1000" for working time.
+func (formatter *DATEVReportFormatter) WithWageType(value PayrollValue, wageType string) {
+	formatter.wageTypes[value] = wageType
+}
+
+// FileExtension returns file extension for DATEV ASCII files: txt.
+func (formatter *DATEVReportFormatter) FileExtension() string {
+	return ".txt"
+}
+
+// WriteMonthlyReportToFile will generate a report outout an writes it to given file.
+func (formatter *DATEVReportFormatter) WriteMonthlyReportToFile(report *MonthlyReport, filename string) error {
+
+	buf, err := formatter.WriteMonthlyReportToBuffer(report)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, buf.Bytes(), 0644)
+}
+
+// WriteMonthlyReportToBuffer returns a buffer for gemerated report output.
+func (formatter *DATEVReportFormatter) WriteMonthlyReportToBuffer(report *MonthlyReport) (*bytes.Buffer, error) {
+
+	if formatter.consultantNumber == "" || formatter.clientNumber == "" {
+		return nil, errors.New("Missing consultant or client number")
+	}
+	personnelNumber, err := formatter.personnelNumberOf(report)
+	if err != nil {
+		return nil, err
+	}
+
+	period := fmt.Sprintf("%02d/%04d", report.Month, report.Year)
+	lines := []string{strings.Join([]string{formatter.consultantNumber, formatter.clientNumber, period}, ";")}
+
+	// Holidays within illness or vacation are not counted, they don't consume vacation days.
+	summary := formatter.summarize(report)
+	values := []struct {
+		value PayrollValue
+		hours time.Duration
+		days  int
+	}{
+		{value: PAYROLL_WORKING_TIME, hours: summary.TotalWorkingTime},
+		{value: PAYROLL_OVERTIME, hours: summary.Overtime},
+		{value: PAYROLL_ILLNESS, days: summary.AbsenceDays[ILLNESS]},
+		{value: PAYROLL_VACATION, days: summary.AbsenceDays[VACATION]},
+	}
+	for _, value := range values {
+		wageType, ok := formatter.wageTypes[value.value]
+		if !ok || (value.hours == 0 && value.days == 0) {
+			continue
+		}
+		hours, days := "", ""
+		if value.hours != 0 {
+			hours = formatDecimalHours(value.hours)
+		}
+		if value.days > 0 {
+			days = fmt.Sprintf("%d", value.days)
+		}
+		lines = append(lines, strings.Join([]string{personnelNumber, period, wageType, hours, days}, ";"))
+	}
+	return bytes.NewBufferString(strings.Join(lines, "\r\n") + "\r\n"), nil
+}
+
+// PersonnelNumberOf returns the personnel number for the device of given report.
+// Device is taken from time tracking records, default personnel number is used if there's no number for a device.
+func (formatter *DATEVReportFormatter) personnelNumberOf(report *MonthlyReport) (string, error) {
+
+	deviceId := ""
+	for _, day := range report.Days {
+		if len(day.Events) > 0 {
+			deviceId = day.Events[0].DeviceId
+			break
+		}
+	}
+	if personnelNumber, ok := formatter.personnelNumbers[deviceId]; ok {
+		return personnelNumber, nil
+	}
+	if personnelNumber, ok := formatter.personnelNumbers[""]; ok {
+		return personnelNumber, nil
+	}
+	return "", fmt.Errorf("Missing personnel number for device %s", deviceId)
+}
+
+// FormatDecimalHours returns given duration, rounded to minutes, as decimal hours with a comma, e.g. 7,50.
+func formatDecimalHours(d time.Duration) string {
+	return strings.Replace(fmt.Sprintf("%.2f", d.Round(time.Minute).Hours()), ".", ",", 1)
+}
@@ -0,0 +1,116 @@
+package timetracker
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DATEVReportFormatterTestSuite struct {
+	suite.Suite
+}
+
+func TestDATEVReportFormatterTestSuite(t *testing.T) {
+	suite.Run(t, new(DATEVReportFormatterTestSuite))
+}
+
+func (suite *DATEVReportFormatterTestSuite) TestGenerateReport() {
+
+	formatter := suite.formatterForTest()
+	report := monthlyReportWithAllTypesForTest()
+	report.Overtime = 90 * time.Minute
+
+	buf, err := formatter.WriteMonthlyReportToBuffer(report)
+	suite.Nil(err)
+	suite.True(strings.HasSuffix(buf.String(), "\r\n"))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\r\n")
+	suite.Len(lines, 5)
+	suite.Equal("12345;67890;01/2022", lines[0])
+	suite.Equal("42;01/2022;1000;17,00;", lines[1])
+	suite.Equal("42;01/2022;1100;1,50;", lines[2])
+	suite.Equal("42;01/2022;2000;;1", lines[3])
+	suite.Equal("42;01/2022;3000;;2", lines[4])
+
+	filename := suite.T().TempDir() + "/report" + formatter.FileExtension()
+	suite.Nil(formatter.WriteMonthlyReportToFile(report, filename))
+	_, err = os.Stat(filename)
+	suite.Nil(err)
+}
+
+func (suite *DATEVReportFormatterTestSuite) TestHolidayWithinVacation() {
+
+	formatter := suite.formatterForTest()
+	formatter.WithHolidays([]Holiday{Holiday{Date: Date{Year: 2022, Month: 1, Day: 12}, Description: "Holiday"}})
+
+	buf, err := formatter.WriteMonthlyReportToBuffer(monthlyReportForTest())
+	suite.Nil(err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\r\n")
+	suite.Len(lines, 4)
+	suite.Equal("42;01/2022;2000;;1", lines[2])
+	suite.Equal("42;01/2022;3000;;1", lines[3])
+}
+
+func (suite *DATEVReportFormatterTestSuite) TestNegativeOvertime() {
+
+	formatter := suite.formatterForTest()
+	report := monthlyReportWithAllTypesForTest()
+	report.Overtime = -2*time.Hour - 15*time.Minute
+
+	buf, err := formatter.WriteMonthlyReportToBuffer(report)
+	suite.Nil(err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\r\n")
+	suite.Len(lines, 5)
+	suite.Equal("42;01/2022;1100;-2,25;", lines[2])
+}
+
+func (suite *DATEVReportFormatterTestSuite) TestSkipValues() {
+
+	formatter := NewDATEVReportFormatter(loggerForTest())
+	formatter.WithClient("12345", "67890")
+	formatter.WithDefaultPersonnelNumber("42")
+	formatter.WithWageType(PAYROLL_WORKING_TIME, "1000")
+	formatter.WithWageType(PAYROLL_OVERTIME, "1100")
+	report := monthlyReportWithAllTypesForTest()
+
+	buf, err := formatter.WriteMonthlyReportToBuffer(report)
+	suite.Nil(err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\r\n")
+	suite.Len(lines, 2)
+	suite.Equal("42;01/2022;1000;17,00;", lines[1])
+}
+
+func (suite *DATEVReportFormatterTestSuite) TestPersonnelNumbers() {
+
+	formatter := suite.formatterForTest()
+	report := monthlyReportWithAllTypesForTest()
+	report.Days[0].Events[0].DeviceId = deviceIdForTest()
+
+	formatter.WithPersonnelNumber(deviceIdForTest(), "4711")
+	buf, err := formatter.WriteMonthlyReportToBuffer(report)
+	suite.Nil(err)
+	suite.Contains(buf.String(), "4711;01/2022;1000;17,00;")
+
+	formatter = NewDATEVReportFormatter(loggerForTest())
+	formatter.WithClient("12345", "67890")
+	_, err = formatter.WriteMonthlyReportToBuffer(report)
+	suite.NotNil(err)
+
+	formatter = NewDATEVReportFormatter(loggerForTest())
+	formatter.WithDefaultPersonnelNumber("42")
+	_, err = formatter.WriteMonthlyReportToBuffer(report)
+	suite.NotNil(err)
+}
+
+func (suite *DATEVReportFormatterTestSuite) formatterForTest() *DATEVReportFormatter {
+	formatter := NewDATEVReportFormatter(loggerForTest())
+	formatter.WithClient("12345", "67890")
+	formatter.WithDefaultPersonnelNumber("42")
+	formatter.WithWageType(PAYROLL_WORKING_TIME, "1000")
+	formatter.WithWageType(PAYROLL_OVERTIME, "1100")
+	formatter.WithWageType(PAYROLL_ILLNESS, "2000")
+	formatter.WithWageType(PAYROLL_VACATION, "3000")
+	return formatter
+}
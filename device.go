@@ -0,0 +1,401 @@
+package timetracker
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+const (
+
+	// DefaultDeviceCacheTTL is the default duration devices of a registry are cached.
+	defaultDeviceCacheTTL = time.Minute
+
+	// MaxDeviceUpdateAttempts is the max number of attempts to store changed devices if they have been modified concurrently.
+	maxDeviceUpdateAttempts = 3
+)
+
+// ErrDevicesModified is returned by a device storage if devices have been modified since they have been loaded.
+var errDevicesModified = errors.New("Devices have been modified concurrently")
+
+// NewFileDeviceRegistry returns a device registry which persists all devices in given JSON file.
+func NewFileDeviceRegistry(filename string) *FileDeviceRegistry {
+	registry := &FileDeviceRegistry{filename: filename, fileMode: 0644}
+	registry.storage = registry
+	registry.cacheTTL = defaultDeviceCacheTTL
+	return registry
+}
+
+// NewS3DeviceRegistry returns a device registry which persists all devices in a JSON object in a S3 bucket.
+func NewS3DeviceRegistry(awsRegion, bucket, basePath *string) *S3DeviceRegistry {
+	registry := &S3DeviceRegistry{
+		bucket:   bucket,
+		basePath: basePath,
+		s3:       newS3Client(awsRegion),
+	}
+	registry.storage = registry
+	registry.cacheTTL = defaultDeviceCacheTTL
+	return registry
+}
+
+// NewReportCalulatorForDevice returns a report calculator using locale and work schedule of given device.
+// Records captured outside of active period of a device will be ignored.
+func NewReportCalulatorForDevice(registry DeviceRegistry, deviceId string, records []TimeTrackingRecord) (*ReportCalulator, error) {
+
+	device, err := registry.GetDevice(deviceId)
+	if err != nil {
+		return nil, err
+	}
+	activeRecords := []TimeTrackingRecord{}
+	for _, record := range records {
+		if device.IsActive(record.Timestamp) {
+			activeRecords = append(activeRecords, record)
+		}
+	}
+	return NewReportCalulator(activeRecords, device.ReportLocale()), nil
+}
+
+// NewCalendarApiForDevice returns a calendar to get holidays for the country defined in locale of given device.
+func NewCalendarApiForDevice(apiKey string, registry DeviceRegistry, deviceId string) (*CalendarApi, error) {
+
+	device, err := registry.GetDevice(deviceId)
+	if err != nil {
+		return nil, err
+	}
+	return NewCalendarApi(apiKey, device.ReportLocale()), nil
+}
+
+// NewEMailPublisherForDevice returns an email publisher which sends reports to owner of given device.
+func NewEMailPublisherForDevice(registry DeviceRegistry, deviceId, source, subject, message string) (*EMailPublisher, error) {
+
+	device, err := registry.GetDevice(deviceId)
+	if err != nil {
+		return nil, err
+	}
+	if device.EMail == "" {
+		return nil, errors.New("No email address for device: " + deviceId)
+	}
+	return NewEMailPublisher(source, device.EMail, subject, message), nil
+}
+
+// ReportLocale returns locale of a device. Work schedule will be replaced by schedule of this device if defined.
+func (device Device) ReportLocale() Locale {
+	locale := device.Locale
+	if len(device.Schedule) > 0 {
+		locale.WorkSchedule = device.Schedule
+	}
+	return locale
+}
+
+// IsActive returns true if given point in time is in active period of a device.
+func (device Device) IsActive(t time.Time) bool {
+	return (device.ActiveFrom == nil || !t.Before(*device.ActiveFrom)) &&
+		(device.ActiveUntil == nil || !t.After(*device.ActiveUntil))
+}
+
+// DeviceStorage is used to load and persist all devices of a registry.
+type deviceStorage interface {
+
+	// LoadDevices returns all persisted devices and their version, an empty version if no devices have been persisted.
+	loadDevices() (map[string]Device, string, error)
+
+	// StoreDevices will persist given devices if persisted devices still have given version.
+	// Returns errDevicesModified if they have been modified in the meantime.
+	storeDevices(map[string]Device, string) error
+}
+
+// DeviceRegistry provides CRUD actions for devices on top of a device storage.
+// Loaded devices are cached and changes are serialized, so concurrent changes don't overwrite each other.
+type deviceRegistry struct {
+	storage  deviceStorage
+	mutex    sync.Mutex
+	cacheTTL time.Duration
+	cache    map[string]Device
+	cachedAt time.Time
+}
+
+// FileDeviceRegistry persists devices in a local JSON file.
+// Changes of other processes are detected by a hash of the file, but writing a file isn't atomic. Use only one
+// process to change devices of a file registry.
+type FileDeviceRegistry struct {
+	deviceRegistry
+	filename string
+	fileMode os.FileMode
+}
+
+// S3DeviceRegistry persists devices in a JSON object in an AWS S3 bucket.
+// Objects are written with a conditional put on the ETag of loaded devices, so changes of other processes are not lost.
+type S3DeviceRegistry struct {
+	deviceRegistry
+	bucket   *string
+	basePath *string
+	s3       *s3.S3
+}
+
+// WithCacheTTL defines how long loaded devices are cached, default is one minute. Changes of a registry
+// itself are applied to the cache, changes of other processes are visible after this duration.
+// A zero duration disables caching.
+func (registry *deviceRegistry) WithCacheTTL(cacheTTL time.Duration) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.cacheTTL = cacheTTL
+	registry.cache = nil
+}
+
+// AddDevice creates a new device. Returns an error if a device with same id already exists.
+func (registry *deviceRegistry) AddDevice(device Device) error {
+	return registry.update(func(devices map[string]Device) error {
+		if _, ok := devices[device.Id]; ok {
+			return errors.New("Device already exists: " + device.Id)
+		}
+		devices[device.Id] = device
+		return nil
+	})
+}
+
+// GetDevice returns a device for given id.
+func (registry *deviceRegistry) GetDevice(deviceId string) (*Device, error) {
+
+	devices, err := registry.devices()
+	if err != nil {
+		return nil, err
+	}
+	if device, ok := devices[deviceId]; ok {
+		return &device, nil
+	}
+	return nil, errors.New("Invalid device id: " + deviceId)
+}
+
+// ListDevices returns all existing devices, sorted by id.
+func (registry *deviceRegistry) ListDevices() ([]Device, error) {
+
+	devices, err := registry.devices()
+	if err != nil {
+		return []Device{}, err
+	}
+	return sortedDevices(devices), nil
+}
+
+// UpdateDevice will replace settings of an existing device.
+func (registry *deviceRegistry) UpdateDevice(device Device) error {
+	return registry.update(func(devices map[string]Device) error {
+		if _, ok := devices[device.Id]; !ok {
+			return errors.New("Invalid device id: " + device.Id)
+		}
+		devices[device.Id] = device
+		return nil
+	})
+}
+
+// DeleteDevice will remove device with given id.
+func (registry *deviceRegistry) DeleteDevice(deviceId string) error {
+	return registry.update(func(devices map[string]Device) error {
+		if _, ok := devices[deviceId]; !ok {
+			return errors.New("Invalid device id: " + deviceId)
+		}
+		delete(devices, deviceId)
+		return nil
+	})
+}
+
+// WorkLocationOf returns default work location of given device.
+func (registry *deviceRegistry) WorkLocationOf(deviceId string) WorkLocation {
+	if device, err := registry.GetDevice(deviceId); err == nil {
+		return device.WorkLocation
+	}
+	return ""
+}
+
+// Devices returns cached devices or loads them from storage if cache has been expired.
+// Returned devices must not be modified, because they're shared with other calls.
+func (registry *deviceRegistry) devices() (map[string]Device, error) {
+
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	if registry.cache != nil && time.Since(registry.cachedAt) < registry.cacheTTL {
+		return registry.cache, nil
+	}
+	devices, _, err := registry.storage.loadDevices()
+	if err != nil {
+		return nil, err
+	}
+	registry.cache, registry.cachedAt = devices, time.Now()
+	return devices, nil
+}
+
+// Update loads current devices from storage, applies given change and stores them. If devices have been modified
+// by another process in the meantime, they're loaded again and given change is applied to them, again.
+func (registry *deviceRegistry) update(change func(map[string]Device) error) error {
+
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.cache = nil
+	for attempt := 1; ; attempt++ {
+		devices, version, err := registry.storage.loadDevices()
+		if err != nil {
+			return err
+		}
+		if err := change(devices); err != nil {
+			return err
+		}
+		err = registry.storage.storeDevices(devices, version)
+		if errors.Is(err, errDevicesModified) && attempt < maxDeviceUpdateAttempts {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		registry.cache, registry.cachedAt = devices, time.Now()
+		return nil
+	}
+}
+
+// LoadDevices reads all devices from registry file. Returns an empty list if file doesn't exist.
+// Version of devices is a hash of the file content.
+func (registry *FileDeviceRegistry) loadDevices() (map[string]Device, string, error) {
+
+	content, err := os.ReadFile(registry.filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return make(map[string]Device), "", nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	devices, err := decodeDevices(content)
+	return devices, contentVersion(content), err
+}
+
+// StoreDevices writes given devices to registry file if file content still has given version.
+func (registry *FileDeviceRegistry) storeDevices(devices map[string]Device, version string) error {
+
+	content, err := encodeDevices(devices)
+	if err != nil {
+		return err
+	}
+	currentContent, err := os.ReadFile(registry.filename)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if contentVersion(currentContent) != version {
+		return errDevicesModified
+	}
+	return os.WriteFile(registry.filename, content, registry.fileMode)
+}
+
+// LoadDevices downloads all devices from S3. Returns an empty list if there's no registry object, yet.
+// Version of devices is the ETag of the registry object.
+func (registry *S3DeviceRegistry) loadDevices() (map[string]Device, string, error) {
+
+	requestInput := &s3.GetObjectInput{
+		Bucket: registry.bucket,
+		Key:    registry.objectKey(),
+	}
+	output, err := registry.s3.GetObject(requestInput)
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == s3.ErrCodeNoSuchKey {
+			return make(map[string]Device), "", nil
+		}
+		return nil, "", err
+	}
+	defer output.Body.Close()
+	content, err := io.ReadAll(output.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	devices, err := decodeDevices(content)
+	if output.ETag == nil {
+		return devices, "", err
+	}
+	return devices, *output.ETag, err
+}
+
+// StoreDevices uploads given devices to S3. Upload is conditional on the ETag of loaded devices,
+// or on a not existing object if there were no devices, yet.
+func (registry *S3DeviceRegistry) storeDevices(devices map[string]Device, version string) error {
+
+	content, err := encodeDevices(devices)
+	if err != nil {
+		return err
+	}
+	putInput := &s3.PutObjectInput{
+		Bucket: registry.bucket,
+		Key:    registry.objectKey(),
+		Body:   bytes.NewReader(content),
+	}
+	request, _ := registry.s3.PutObjectRequest(putInput)
+	if version == "" {
+		request.HTTPRequest.Header.Set("If-None-Match", "*")
+	} else {
+		request.HTTPRequest.Header.Set("If-Match", version)
+	}
+	if err := request.Send(); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && isConditionalPutFailure(awsErr.Code()) {
+			return errDevicesModified
+		}
+		return err
+	}
+	return nil
+}
+
+// IsConditionalPutFailure returns true for S3 error codes of a conditional put which failed, because an object
+// has been changed or created by someone else.
+func isConditionalPutFailure(errorCode string) bool {
+	return errorCode == "PreconditionFailed" || errorCode == "ConditionalRequestConflict"
+}
+
+// ObjectKey returns S3 object key of device registry.
+// Will add a path prefix if it has been defined at creating this registry.
+func (registry *S3DeviceRegistry) objectKey() *string {
+	key := "devices.json"
+	if registry.basePath != nil {
+		key = *registry.basePath + "/" + key
+	}
+	return &key
+}
+
+// ContentVersion returns a hash of given content, or an empty version for empty content.
+func contentVersion(content []byte) string {
+	if len(content) == 0 {
+		return ""
+	}
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:])
+}
+
+// EncodeDevices converts given devices to a JSON list.
+func encodeDevices(devices map[string]Device) ([]byte, error) {
+	return json.MarshalIndent(sortedDevices(devices), "", "    ")
+}
+
+// SortedDevices returns given devices as a list, sorted by device id.
+func sortedDevices(devices map[string]Device) []Device {
+	listOfDevices := []Device{}
+	for _, device := range devices {
+		listOfDevices = append(listOfDevices, device)
+	}
+	sort.Slice(listOfDevices, func(i, j int) bool { return listOfDevices[i].Id < listOfDevices[j].Id })
+	return listOfDevices
+}
+
+// DecodeDevices reads devices from given JSON list.
+func decodeDevices(content []byte) (map[string]Device, error) {
+	var listOfDevices []Device
+	if err := json.Unmarshal(content, &listOfDevices); err != nil {
+		return nil, err
+	}
+	devices := make(map[string]Device)
+	for _, device := range listOfDevices {
+		devices[device.Id] = device
+	}
+	return devices, nil
+}
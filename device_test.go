@@ -0,0 +1,212 @@
+package timetracker
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DeviceRegistryTestSuite struct {
+	suite.Suite
+}
+
+func TestDeviceRegistryTestSuite(t *testing.T) {
+	suite.Run(t, new(DeviceRegistryTestSuite))
+}
+
+func (suite *DeviceRegistryTestSuite) skipCI() {
+	if _, isSet := os.LookupEnv("CI"); isSet {
+		suite.T().Skip("Skip test in CI environment.")
+	}
+}
+
+func (suite *DeviceRegistryTestSuite) TestFileDeviceRegistry() {
+
+	filename := suite.T().TempDir() + "/devices.json"
+	suite.assertCrudActions(NewFileDeviceRegistry(filename))
+
+	registry := NewFileDeviceRegistry(filename)
+	devices, err := registry.ListDevices()
+	suite.Nil(err)
+	suite.Len(devices, 1)
+	suite.Equal(localeForTest(), devices[0].Locale)
+}
+
+func (suite *DeviceRegistryTestSuite) TestCachedDevices() {
+
+	filename := suite.T().TempDir() + "/devices.json"
+	registry := NewFileDeviceRegistry(filename)
+	suite.Nil(registry.AddDevice(deviceForTest("Device01")))
+	suite.Equal(HOME, registry.WorkLocationOf("Device01"))
+
+	device := deviceForTest("Device01")
+	device.WorkLocation = OFFICE
+	suite.Nil(NewFileDeviceRegistry(filename).UpdateDevice(device))
+	suite.Equal(HOME, registry.WorkLocationOf("Device01"))
+
+	registry.WithCacheTTL(0)
+	suite.Equal(OFFICE, registry.WorkLocationOf("Device01"))
+
+	// Changes are applied to latest devices in storage, not to cached devices.
+	registry.WithCacheTTL(time.Hour)
+	suite.Nil(registry.AddDevice(deviceForTest("Device02")))
+	suite.Nil(NewFileDeviceRegistry(filename).DeleteDevice("Device02"))
+	suite.NotNil(registry.UpdateDevice(deviceForTest("Device02")))
+	suite.Equal(OFFICE, registry.WorkLocationOf("Device01"))
+}
+
+func (suite *DeviceRegistryTestSuite) TestConcurrentRegistrations() {
+
+	registry := NewFileDeviceRegistry(suite.T().TempDir() + "/devices.json")
+	wg := sync.WaitGroup{}
+	for idx := 0; idx < 20; idx++ {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			suite.Nil(registry.AddDevice(deviceForTest(fmt.Sprintf("Device%02d", idx))))
+		}(idx)
+	}
+	wg.Wait()
+
+	devices, err := registry.ListDevices()
+	suite.Nil(err)
+	suite.Len(devices, 20)
+}
+
+func (suite *DeviceRegistryTestSuite) TestConcurrentModification() {
+
+	storage := &deviceStorageForTest{devices: make(map[string]Device), conflicts: 1}
+	registry := &deviceRegistry{storage: storage}
+	suite.Nil(registry.AddDevice(deviceForTest("Device01")))
+	suite.Equal(2, storage.stores)
+	suite.Len(storage.devices, 1)
+
+	storage.conflicts = maxDeviceUpdateAttempts
+	suite.Equal(errDevicesModified, registry.AddDevice(deviceForTest("Device02")))
+	suite.Len(storage.devices, 1)
+
+	filename := suite.T().TempDir() + "/devices.json"
+	fileRegistry := NewFileDeviceRegistry(filename)
+	devices, version, err := fileRegistry.loadDevices()
+	suite.Nil(err)
+	suite.Nil(NewFileDeviceRegistry(filename).AddDevice(deviceForTest("Device01")))
+	devices["Device02"] = deviceForTest("Device02")
+	suite.Equal(errDevicesModified, fileRegistry.storeDevices(devices, version))
+}
+
+func (suite *DeviceRegistryTestSuite) TestS3DeviceRegistry() {
+
+	suite.skipCI()
+
+	bucket, ok := os.LookupEnv("AWS_S3_TEST_BUCKET")
+	if !ok {
+		suite.T().Skip("Skip test without AWS_S3_TEST_BUCKET.")
+	}
+	if _, err := newAwsSession(nil).Config.Credentials.Get(); err != nil {
+		suite.T().Skip("Skip test without AWS credentials.")
+	}
+	path := "timetracker-test"
+	registry := NewS3DeviceRegistry(nil, &bucket, &path)
+	suite.assertCrudActions(registry)
+	suite.Nil(registry.DeleteDevice("Device02"))
+}
+
+func (suite *DeviceRegistryTestSuite) TestDrivenByDeviceId() {
+
+	registry := NewFileDeviceRegistry(suite.T().TempDir() + "/devices.json")
+	suite.Nil(registry.AddDevice(deviceForTest("Device01")))
+
+	records := []TimeTrackingRecord{
+		TimeTrackingRecord{DeviceId: "Device01", Type: WORKDAY, Timestamp: asTime("2021-12-31T08:00:00")},
+		TimeTrackingRecord{DeviceId: "Device01", Type: WORKDAY, Timestamp: asTime("2022-02-01T08:00:00")},
+		TimeTrackingRecord{DeviceId: "Device01", Type: WORKDAY, Timestamp: asTime("2022-02-01T16:00:00")},
+	}
+	calculator, err := NewReportCalulatorForDevice(registry, "Device01", records)
+	suite.Nil(err)
+	suite.Len(calculator.records, 2)
+	suite.Equal(workScheduleForTest(), calculator.location.WorkSchedule)
+
+	calendar, err := NewCalendarApiForDevice("api-key", registry, "Device01")
+	suite.Nil(err)
+	suite.Equal("de", calendar.location.Country)
+
+	publisher, err := NewEMailPublisherForDevice(registry, "Device01", "reports@example.com", "Report", "")
+	suite.Nil(err)
+	suite.Equal("jane.doe@example.com", publisher.Destination)
+
+	suite.Equal(HOME, registry.WorkLocationOf("Device01"))
+	suite.Equal(WorkLocation(""), registry.WorkLocationOf("Device02"))
+
+	_, err = NewReportCalulatorForDevice(registry, "Device02", records)
+	suite.NotNil(err)
+}
+
+func (suite *DeviceRegistryTestSuite) assertCrudActions(registry DeviceRegistry) {
+
+	device := deviceForTest("Device02")
+	suite.Nil(registry.AddDevice(device))
+	suite.NotNil(registry.AddDevice(device))
+
+	device1, err := registry.GetDevice(device.Id)
+	suite.Require().NoError(err)
+	suite.Equal(device.Owner, device1.Owner)
+	suite.True(device.ActiveFrom.Equal(*device1.ActiveFrom))
+
+	device.Owner = "John Doe"
+	suite.Nil(registry.UpdateDevice(device))
+	device2, err := registry.GetDevice(device.Id)
+	suite.Require().NoError(err)
+	suite.Equal("John Doe", device2.Owner)
+
+	suite.Nil(registry.AddDevice(deviceForTest("Device03")))
+	devices, err := registry.ListDevices()
+	suite.Nil(err)
+	suite.True(len(devices) >= 2)
+
+	suite.Nil(registry.DeleteDevice("Device03"))
+	suite.NotNil(registry.DeleteDevice("Device03"))
+	suite.NotNil(registry.UpdateDevice(deviceForTest("Device03")))
+	_, err = registry.GetDevice("Device03")
+	suite.NotNil(err)
+}
+
+func deviceForTest(deviceId string) Device {
+	activeFrom := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return Device{
+		Id:           deviceId,
+		Owner:        "Jane Doe",
+		EMail:        "jane.doe@example.com",
+		Locale:       localeForTest(),
+		Schedule:     workScheduleForTest(),
+		WorkLocation: HOME,
+		ActiveFrom:   &activeFrom,
+	}
+}
+
+type deviceStorageForTest struct {
+	devices   map[string]Device
+	conflicts int
+	stores    int
+}
+
+func (storage *deviceStorageForTest) loadDevices() (map[string]Device, string, error) {
+	devices := make(map[string]Device)
+	for id, device := range storage.devices {
+		devices[id] = device
+	}
+	return devices, fmt.Sprintf("%d", storage.stores), nil
+}
+
+func (storage *deviceStorageForTest) storeDevices(devices map[string]Device, version string) error {
+	storage.stores++
+	if storage.conflicts > 0 {
+		storage.conflicts--
+		return errDevicesModified
+	}
+	storage.devices = devices
+	return nil
+}
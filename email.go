@@ -5,15 +5,25 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"html"
+	htmltemplate "html/template"
+	"mime"
 	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
 	"net/textproto"
+	"path/filepath"
+	"regexp"
 	"strings"
+	"text/template"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ses"
 )
 
 // NewEMailPublisher creates a new publsher to send time tracking reports via email.
+// Destination can be a comma separated list of multiple recipients.
 func NewEMailPublisher(source, destination, subject, message string) *EMailPublisher {
 	return &EMailPublisher{
 		Source:      source,
@@ -26,12 +36,145 @@ func NewEMailPublisher(source, destination, subject, message string) *EMailPubli
 // EMailPublisher delivers time tracking reports via email.
 type EMailPublisher struct {
 	Source, Destination, Subject, Message string
+
+	// EMailOptions contains additional recipients and a plain text message.
+	eMailOptions
+}
+
+// EMailOptions contains settings shared by all email publishers.
+type eMailOptions struct {
+
+	// CC and BCC are additional recipients. BCC recipients are not written to email headers.
+	cc, bcc []string
+
+	// TextMessage is used as plain text alternative of a HTML message.
+	// If it's missing, plain text is generated from a HTML message.
+	textMessage string
+
+	// Templates for subject, HTML message and plain text message, rendered with a monthly report.
+	// A template replaces a static subject or message.
+	subjectTemplate, textTemplate *template.Template
+	messageTemplate               *htmltemplate.Template
+
+	// Report is used to render templates of emails sent with Send.
+	report *MonthlyReport
+
+	// Holidays are excluded from absence days in a report summary.
+	holidays []Holiday
+
+	// LinkProvider returns links to reports which are sent instead of attachments.
+	linkProvider ReportLinkProvider
+
+	// MaxAttachmentSize is the max size of an attachment in bytes, larger reports are linked.
+	maxAttachmentSize int
+}
+
+// WithCC adds recipients who get a copy of an email.
+func (options *eMailOptions) WithCC(addresses ...string) {
+	options.cc = append(options.cc, addresses...)
+}
+
+// WithBCC adds recipients who get a blind copy of an email.
+func (options *eMailOptions) WithBCC(addresses ...string) {
+	options.bcc = append(options.bcc, addresses...)
 }
 
-// Send will deliver given time tracking report via email.
+// WithTextMessage defines a plain text alternative of a HTML message.
+func (options *eMailOptions) WithTextMessage(textMessage string) {
+	options.textMessage = textMessage
+}
+
+// WithReportLink sends a link to a report instead of attaching it, if a report is larger than given
+// max attachment size in bytes. Reports are always linked if max attachment size is zero.
+// Link provider is usually a S3Publisher, which has to upload a report before an email is sent. In a FanOutPublisher
+// add an email publisher with WithTargetAfter its S3 target, because all other targets are published concurrently.
+func (options *eMailOptions) WithReportLink(linkProvider ReportLinkProvider, maxAttachmentSize int) {
+	options.linkProvider = linkProvider
+	options.maxAttachmentSize = maxAttachmentSize
+}
+
+// ReportLinkOf returns a link to given report if it should be linked instead of attached, otherwise an empty string.
+func (options *eMailOptions) reportLinkOf(content []byte, fileName string) (string, error) {
+	if options.linkProvider == nil || (options.maxAttachmentSize > 0 && len(content) <= options.maxAttachmentSize) {
+		return "", nil
+	}
+	return options.linkProvider.ReportLink(fileName)
+}
+
+// NewEMail returns an email for given values and options. If a report link is passed,
+// it's added to messages and a report is not attached. Templates are rendered with given report.
+func (options *eMailOptions) newEMail(source, destination, subject, message, reportLink string, report *MonthlyReport) (*eMail, error) {
+
+	from, err := mail.ParseAddress(source)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid email source %s: %s", source, err)
+	}
+	to, err := mail.ParseAddressList(destination)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid email destination %s: %s", destination, err)
+	}
+	cc, err := parseAddresses(options.cc)
+	if err != nil {
+		return nil, err
+	}
+	bcc, err := parseAddresses(options.bcc)
+	if err != nil {
+		return nil, err
+	}
+	eMail := &eMail{
+		from:        from,
+		to:          to,
+		cc:          cc,
+		bcc:         bcc,
+		subject:     subject,
+		message:     message,
+		textMessage: options.textMessage,
+		reportLink:  reportLink,
+	}
+	if err := options.renderTemplates(eMail, report); err != nil {
+		return nil, err
+	}
+	options.addReportLink(eMail, report)
+	return eMail, nil
+}
+
+// AddReportLink appends a report link, labeled in language of given report, to messages which are not rendered by a template.
+// Templates can add a link by themselves, it's passed as .ReportLink.
+func (options *eMailOptions) addReportLink(eMail *eMail, report *MonthlyReport) {
+
+	if eMail.reportLink == "" {
+		return
+	}
+	label := eMailMessagesOf(report).message(msgOpenReport)
+	if options.textTemplate == nil {
+		if eMail.textMessage == "" {
+			eMail.textMessage = plainTextOf(eMail.message)
+		}
+		eMail.textMessage += "\n\n" + label + ": " + eMail.reportLink
+	}
+	if options.messageTemplate == nil {
+		eMail.message += fmt.Sprintf("<p><a href=\"%s\">%s</a></p>", html.EscapeString(eMail.reportLink), html.EscapeString(label))
+	}
+}
+
+// Send will deliver given time tracking report via email. Templates are rendered with the report assigned by WithMonthlyReport.
 func (publisher *EMailPublisher) Send(content []byte, fileName string) error {
+	return publisher.SendMonthlyReport(content, fileName, publisher.report)
+}
 
-	rawEMail, err := rawEMail(publisher.Source, publisher.Destination, publisher.Subject, publisher.Message, content, fileName)
+// SendMonthlyReport will deliver given time tracking report via email and renders templates with given monthly report.
+// A passed report is not stored, so a publisher can be used by concurrent sends of different reports.
+func (publisher *EMailPublisher) SendMonthlyReport(content []byte, fileName string, report *MonthlyReport) error {
+
+	reportLink, err := publisher.reportLinkOf(content, fileName)
+	if err != nil {
+		return err
+	}
+	eMail, err := publisher.newEMail(publisher.Source, publisher.Destination, publisher.Subject, publisher.Message, reportLink, report)
+	if err != nil {
+		return err
+	}
+	rawEMail, err := rawEMail(eMail, content, fileName)
 	if err != nil {
 		return err
 	}
@@ -40,8 +183,8 @@ func (publisher *EMailPublisher) Send(content []byte, fileName string) error {
 		Data: []byte(*rawEMail),
 	}
 	sendRawEmailInput := &ses.SendRawEmailInput{
-		Destinations: []*string{aws.String(publisher.Destination)},
-		Source:       aws.String(publisher.Source),
+		Destinations: aws.StringSlice(eMail.recipients()),
+		Source:       aws.String(eMail.from.Address),
 		RawMessage:   &rawMessage,
 	}
 
@@ -50,70 +193,197 @@ func (publisher *EMailPublisher) Send(content []byte, fileName string) error {
 	return sendErr
 }
 
-// RawEMail generates a raw email with given sender/receiver, subject, message and attachment.
-func rawEMail(source, destination, subject, message string, csvFile []byte, attachmentFilename string) (*string, error) {
+// EMail contains sender, recipients, subject and messages of an email.
+type eMail struct {
+	from                 *mail.Address
+	to, cc, bcc          []*mail.Address
+	subject              string
+	message, textMessage string
+
+	// ReportLink is sent instead of an attachment, if it's not empty.
+	reportLink string
+}
 
-	if !strings.HasSuffix(attachmentFilename, "xlsx") {
-		return nil, errors.New("Excel (xlsx) supported only atm!")
+// Recipients returns addresses of all recipients, including BCC.
+func (eMail *eMail) recipients() []string {
+	recipients := []string{}
+	for _, addresses := range [][]*mail.Address{eMail.to, eMail.cc, eMail.bcc} {
+		for _, address := range addresses {
+			recipients = append(recipients, address.Address)
+		}
+	}
+	return recipients
+}
+
+// RawEMail generates a raw email with a HTML message, a plain text alternative and given attachment.
+// Content type of an attachment is derived from its file extension. Attachment is omitted if an email contains a report link.
+func rawEMail(eMail *eMail, attachment []byte, attachmentFilename string) (*string, error) {
+
+	if len(eMail.to) == 0 {
+		return nil, errors.New("Missing email destination")
 	}
 
 	buf := new(bytes.Buffer)
 	writer := multipart.NewWriter(buf)
 
-	h := make(textproto.MIMEHeader)
-	h.Set("From", source)
-	h.Set("To", destination)
-	h.Set("Return-Path", source)
-	h.Set("Subject", subject)
-	h.Set("Content-Language", "en-US")
-	h.Set("Content-Type", "multipart/mixed; boundary=\""+writer.Boundary()+"\"")
-	h.Set("MIME-Version", "1.0")
-	_, err := writer.CreatePart(h)
-	if err != nil {
+	buf.WriteString("From: " + eMail.from.String() + "\r\n")
+	buf.WriteString("To: " + joinAddresses(eMail.to) + "\r\n")
+	if len(eMail.cc) > 0 {
+		buf.WriteString("Cc: " + joinAddresses(eMail.cc) + "\r\n")
+	}
+	buf.WriteString("Return-Path: " + eMail.from.Address + "\r\n")
+	buf.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", eMail.subject) + "\r\n")
+	buf.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: multipart/mixed; boundary=\"" + writer.Boundary() + "\"\r\n\r\n")
+
+	alternativeBuf := new(bytes.Buffer)
+	alternativeWriter := multipart.NewWriter(alternativeBuf)
+	textMessage := eMail.textMessage
+	if textMessage == "" {
+		textMessage = plainTextOf(eMail.message)
+	}
+	if err := writeQuotedPrintablePart(alternativeWriter, "text/plain; charset=utf-8", textMessage); err != nil {
+		return nil, err
+	}
+	if err := writeQuotedPrintablePart(alternativeWriter, "text/html; charset=utf-8", eMail.message); err != nil {
+		return nil, err
+	}
+	if err := alternativeWriter.Close(); err != nil {
 		return nil, err
 	}
 
-	h = make(textproto.MIMEHeader)
-	h.Set("Content-Transfer-Encoding", "7bit")
-	h.Set("Content-Type", "text/html; charset=us-ascii")
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Type", "multipart/alternative; boundary=\""+alternativeWriter.Boundary()+"\"")
 	part, err := writer.CreatePart(h)
 	if err != nil {
 		return nil, err
 	}
-	_, err = part.Write([]byte(message))
-	if err != nil {
+	if _, err = part.Write(alternativeBuf.Bytes()); err != nil {
 		return nil, err
 	}
 
-	fn := attachmentFilename
+	if eMail.reportLink != "" {
+		return closeRawEMail(buf, writer)
+	}
+
 	h = make(textproto.MIMEHeader)
-	h.Set("Content-Disposition", "attachment; filename="+fn)
-	h.Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet; x-unix-mode=0644; name=\""+fn+"\"")
+	h.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachmentFilename}))
+	h.Set("Content-Type", mime.FormatMediaType(contentTypeOf(attachmentFilename), map[string]string{"name": attachmentFilename}))
 	h.Set("Content-Transfer-Encoding", "base64")
-	//h.Set("Content-Transfer-Encoding", "7bit")
 	part, err = writer.CreatePart(h)
 	if err != nil {
 		return nil, err
 	}
+	if _, err = part.Write(base64Lines(attachment)); err != nil {
+		return nil, err
+	}
+	return closeRawEMail(buf, writer)
+}
 
-	b := make([]byte, base64.StdEncoding.EncodedLen(len(csvFile)))
-	base64.StdEncoding.Encode(b, csvFile)
-
-	_, err = part.Write(b)
-	//_, err = part.Write(csvFile)
-	if err != nil {
+// CloseRawEMail writes the final boundary of an email and returns its content.
+func closeRawEMail(buf *bytes.Buffer, writer *multipart.Writer) (*string, error) {
+	if err := writer.Close(); err != nil {
 		return nil, err
 	}
+	s := buf.String()
+	return &s, nil
+}
 
-	err = writer.Close()
+// WriteQuotedPrintablePart adds a part with given content type and quoted-printable encoded content.
+func writeQuotedPrintablePart(writer *multipart.Writer, contentType, content string) error {
+
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Type", contentType)
+	h.Set("Content-Transfer-Encoding", "quoted-printable")
+	part, err := writer.CreatePart(h)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	qpWriter := quotedprintable.NewWriter(part)
+	if _, err := qpWriter.Write([]byte(content)); err != nil {
+		return err
 	}
+	return qpWriter.Close()
+}
 
-	s := buf.String()
-	if strings.Count(s, "\n") < 2 {
-		return nil, fmt.Errorf("invalid e-mail content")
+// AttachmentContentTypes contains content types of all report file extensions.
+var attachmentContentTypes = map[string]string{
+	".xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	".csv":  "text/csv",
+	".pdf":  "application/pdf",
+	".json": "application/json",
+	".ics":  "text/calendar",
+	".html": "text/html",
+	".md":   "text/markdown",
+	".txt":  "text/plain",
+}
+
+// ContentTypeOf returns content type of a file, derived from its extension.
+// Default is application/octet-stream for unknown extensions.
+func contentTypeOf(fileName string) string {
+	extension := strings.ToLower(filepath.Ext(fileName))
+	if contentType, ok := attachmentContentTypes[extension]; ok {
+		return contentType
 	}
-	s = strings.SplitN(s, "\n", 2)[1]
-	return &s, nil
+	if contentType := mime.TypeByExtension(extension); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
+
+// Base64Lines returns base64 encoded content, split into lines of 76 characters.
+func base64Lines(content []byte) []byte {
+	encoded := base64.StdEncoding.EncodeToString(content)
+	buf := new(bytes.Buffer)
+	for len(encoded) > 76 {
+		buf.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	buf.WriteString(encoded)
+	return buf.Bytes()
+}
+
+var (
+	// HtmlLineBreaks matches tags which end a line in plain text.
+	htmlLineBreaks = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|h[1-6]|li|tr)>`)
+
+	// HtmlTags matches all HTML tags.
+	htmlTags = regexp.MustCompile(`<[^>]*>`)
+
+	// BlankLines matches three or more line breaks.
+	blankLines = regexp.MustCompile(`\n{3,}`)
+)
+
+// PlainTextOf returns a plain text version of a HTML message, without tags and entities.
+func plainTextOf(htmlMessage string) string {
+	text := htmlLineBreaks.ReplaceAllString(htmlMessage, "\n")
+	text = html.UnescapeString(htmlTags.ReplaceAllString(text, ""))
+	lines := strings.Split(text, "\n")
+	for idx, line := range lines {
+		lines[idx] = strings.TrimSpace(line)
+	}
+	return strings.TrimSpace(blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
+
+// ParseAddresses parses all given email addresses.
+func parseAddresses(addresses []string) ([]*mail.Address, error) {
+	parsedAddresses := []*mail.Address{}
+	for _, address := range addresses {
+		parsedAddress, err := mail.ParseAddress(address)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid email address %s: %s", address, err)
+		}
+		parsedAddresses = append(parsedAddresses, parsedAddress)
+	}
+	return parsedAddresses, nil
+}
+
+// JoinAddresses returns a list of addresses for an email header, names are encoded if required.
+func joinAddresses(addresses []*mail.Address) string {
+	values := []string{}
+	for _, address := range addresses {
+		values = append(values, address.String())
+	}
+	return strings.Join(values, ", ")
 }
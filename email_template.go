@@ -0,0 +1,165 @@
+package timetracker
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// EMailTemplateData is passed to templates of email subjects and messages.
+type EMailTemplateData struct {
+
+	// Report is the monthly report which is sent.
+	Report *MonthlyReport
+
+	// Summary contains key figures and compliance violations of a report.
+	Summary ReportSummary
+
+	// MonthName is the name of a report month in language of report locale, e.g. March.
+	MonthName string
+
+	// ReportLink is a link to a report which is sent instead of an attachment, empty if a report is attached.
+	ReportLink string
+}
+
+// WithMonthlyReport assigns the report which is used to render templates of emails sent with Send.
+// It's kept until another report is assigned, so a publisher must not be shared by concurrent sends
+// of different reports. Use SendMonthlyReport to pass a report with each email instead.
+func (options *eMailOptions) WithMonthlyReport(report *MonthlyReport) {
+	options.report = report
+}
+
+// WithHolidays assigns public holidays, which are not counted as absence days in a report summary.
+func (options *eMailOptions) WithHolidays(holidays []Holiday) {
+	options.holidays = holidays
+}
+
+// WithSubjectTemplate defines a text/template for email subjects, e.g. "Time Tracking Report {{.MonthName}} {{.Report.Year}}".
+func (options *eMailOptions) WithSubjectTemplate(subjectTemplate string) error {
+	parsedTemplate, err := template.New("subject").Funcs(templateFuncsOf(nil)).Parse(subjectTemplate)
+	if err != nil {
+		return err
+	}
+	options.subjectTemplate = parsedTemplate
+	return nil
+}
+
+// WithMessageTemplate defines a html/template for HTML messages, all values are escaped.
+func (options *eMailOptions) WithMessageTemplate(messageTemplate string) error {
+	parsedTemplate, err := htmltemplate.New("message").Funcs(templateFuncsOf(nil)).Parse(messageTemplate)
+	if err != nil {
+		return err
+	}
+	options.messageTemplate = parsedTemplate
+	return nil
+}
+
+// WithTextMessageTemplate defines a text/template for plain text messages.
+func (options *eMailOptions) WithTextMessageTemplate(textTemplate string) error {
+	parsedTemplate, err := template.New("text").Funcs(templateFuncsOf(nil)).Parse(textTemplate)
+	if err != nil {
+		return err
+	}
+	options.textTemplate = parsedTemplate
+	return nil
+}
+
+// RenderTemplates replaces subject and messages of given email by templates rendered with given report, if there're any.
+func (options *eMailOptions) renderTemplates(eMail *eMail, report *MonthlyReport) error {
+
+	if options.subjectTemplate == nil && options.messageTemplate == nil && options.textTemplate == nil {
+		return nil
+	}
+	if report == nil {
+		return errors.New("Missing report to render email templates")
+	}
+
+	data := EMailTemplateData{
+		Report:     report,
+		Summary:    SummarizeMonthlyReportWithHolidays(report, options.holidays),
+		MonthName:  messagesFor(report.Location).month(time.Month(report.Month)),
+		ReportLink: eMail.reportLink,
+	}
+	// Templates are cloned, so helper functions are bound to given report without changing shared templates.
+	funcs := templateFuncsOf(report)
+	if options.subjectTemplate != nil {
+		subjectTemplate, err := options.subjectTemplate.Clone()
+		if err != nil {
+			return err
+		}
+		subject, err := executeTemplate(subjectTemplate.Funcs(funcs), data)
+		if err != nil {
+			return err
+		}
+		// Line breaks are not allowed in email headers.
+		eMail.subject = strings.Join(strings.Fields(subject), " ")
+	}
+	if options.messageTemplate != nil {
+		messageTemplate, err := options.messageTemplate.Clone()
+		if err != nil {
+			return err
+		}
+		message, err := executeTemplate(messageTemplate.Funcs(funcs), data)
+		if err != nil {
+			return err
+		}
+		eMail.message = message
+	}
+	if options.textTemplate != nil {
+		textTemplate, err := options.textTemplate.Clone()
+		if err != nil {
+			return err
+		}
+		textMessage, err := executeTemplate(textTemplate.Funcs(funcs), data)
+		if err != nil {
+			return err
+		}
+		eMail.textMessage = textMessage
+	}
+	return nil
+}
+
+// TemplateFuncsOf returns helper functions available in email templates.
+// Labels, dates and weekdays are written in language and format of given report locale, defaults are used without a report.
+func templateFuncsOf(report *MonthlyReport) map[string]any {
+	messages := eMailMessagesOf(report)
+	return map[string]any{
+		"formatDuration": formatDuration,
+		"decimalHours": func(d time.Duration) string {
+			return fmt.Sprintf("%.2f", d.Round(time.Minute).Hours())
+		},
+		"formatDate": func(date Date) string {
+			if report != nil && report.Location.DateFormat != nil {
+				return date.AsTime().Format(*report.Location.DateFormat)
+			}
+			return date.String()
+		},
+		"label": func(key string) string {
+			return messages.message(messageKey(key))
+		},
+		"weekday": func(date Date) string {
+			return messages.weekday(date.AsTime().Weekday())
+		},
+	}
+}
+
+// EMailMessagesOf returns the message catalog for locale of given report, English labels are used without a report.
+func eMailMessagesOf(report *MonthlyReport) messageCatalog {
+	if report != nil {
+		return messagesFor(report.Location)
+	}
+	return messageCatalogs[ENGLISH]
+}
+
+// ExecuteTemplate renders given template and returns its output.
+func executeTemplate(emailTemplate reportTemplate, data EMailTemplateData) (string, error) {
+	buf := new(bytes.Buffer)
+	if err := emailTemplate.Execute(buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
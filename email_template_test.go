@@ -0,0 +1,110 @@
+package timetracker
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type EMailTemplateTestSuite struct {
+	suite.Suite
+}
+
+func TestEMailTemplateTestSuite(t *testing.T) {
+	suite.Run(t, new(EMailTemplateTestSuite))
+}
+
+func (suite *EMailTemplateTestSuite) TestRenderTemplates() {
+
+	publisher := NewEMailPublisher("reports@example.com", "jane@example.com", "Static Subject", "<p>Static</p>")
+	var _ ReportSummaryPublisher = publisher
+	var _ ReportSummaryPublisher = &SMTPPublisher{}
+	suite.Nil(publisher.WithSubjectTemplate("{{label \"working_time\"}} {{.MonthName}}\n{{.Report.Year}}"))
+	suite.Nil(publisher.WithMessageTemplate("<p>{{.MonthName}}: {{formatDuration .Report.TotalWorkingTime}} ({{decimalHours .Summary.Overtime}})</p><p>{{.Note}}</p>"))
+	suite.Nil(publisher.WithTextMessageTemplate("{{range $type, $days := .Summary.AbsenceDays}}{{label (printf \"%s\" $type)}}: {{$days}}\n{{end}}{{if not .Summary.IsCompliant}}{{len .Summary.Violations}} Verstöße{{end}}"))
+
+	report := monthlyReportWithAllTypesForTest()
+	report.Overtime = 90 * time.Minute
+	_, err := publisher.newEMail(publisher.Source, publisher.Destination, publisher.Subject, publisher.Message, "", nil)
+	suite.NotNil(err)
+
+	eMail, err := publisher.newEMail(publisher.Source, publisher.Destination, publisher.Subject, publisher.Message, "", report)
+	suite.NotNil(err)
+	suite.Nil(eMail)
+
+	suite.Nil(publisher.WithMessageTemplate("<p>{{.MonthName}}: {{formatDuration .Report.TotalWorkingTime}} ({{decimalHours .Summary.Overtime}}) {{label \"<b>\"}}</p>"))
+	eMail, err = publisher.newEMail(publisher.Source, publisher.Destination, publisher.Subject, publisher.Message, "", report)
+	suite.Nil(err)
+	suite.Equal("Arbeitszeit Januar 2022", eMail.subject)
+	suite.Equal("<p>Januar: 17:00 (1.50) &lt;b&gt;</p>", eMail.message)
+	suite.Contains(eMail.textMessage, "Krankheit: 1\n")
+	suite.Contains(eMail.textMessage, "Urlaub: 2\n")
+
+	publisher.WithHolidays([]Holiday{Holiday{Date: Date{Year: 2022, Month: 1, Day: 11}, Description: "Holiday"}})
+	eMail, err = publisher.newEMail(publisher.Source, publisher.Destination, publisher.Subject, publisher.Message, "", report)
+	suite.Nil(err)
+	suite.Contains(eMail.textMessage, "Urlaub: 1\n")
+}
+
+func (suite *EMailTemplateTestSuite) TestRenderTemplatesConcurrently() {
+
+	options := &eMailOptions{}
+	suite.Nil(options.WithSubjectTemplate("{{label \"working_time\"}} {{.MonthName}}"))
+	january := monthlyReportForTest()
+	february := monthlyReportForTest()
+	february.Month = 2
+	february.Location.Language = ENGLISH
+
+	wg := sync.WaitGroup{}
+	for idx := 0; idx < 10; idx++ {
+		wg.Add(1)
+		go func(report *MonthlyReport, expectedSubject string) {
+			defer wg.Done()
+			eMail, err := options.newEMail("reports@example.com", "jane@example.com", "Report", "", "", report)
+			suite.Nil(err)
+			suite.Equal(expectedSubject, eMail.subject)
+		}([]*MonthlyReport{january, february}[idx%2], []string{"Arbeitszeit Januar", "WorkingTime February"}[idx%2])
+	}
+	wg.Wait()
+}
+
+func (suite *EMailTemplateTestSuite) TestStaticSubjectAndMessage() {
+
+	options := &eMailOptions{}
+	eMail, err := options.newEMail("reports@example.com", "jane@example.com", "Report", "<p>Report</p>", "", monthlyReportForTest())
+	suite.Nil(err)
+	suite.Equal("Report", eMail.subject)
+	suite.Equal("<p>Report</p>", eMail.message)
+	suite.Equal("", eMail.textMessage)
+}
+
+func (suite *EMailTemplateTestSuite) TestReportLinkInTemplates() {
+
+	options := &eMailOptions{}
+	suite.Nil(options.WithMessageTemplate(`<p><a href="{{.ReportLink}}">{{label "open_report"}}</a></p>`))
+	eMail, err := options.newEMail("reports@example.com", "jane@example.com", "Report", "", "https://example.com/report.xlsx", monthlyReportForTest())
+	suite.Nil(err)
+	suite.Equal("<p><a href=\"https://example.com/report.xlsx\">Bericht öffnen</a></p>", eMail.message)
+	suite.Equal("Bericht öffnen\n\nBericht öffnen: https://example.com/report.xlsx", eMail.textMessage)
+}
+
+func (suite *EMailTemplateTestSuite) TestInvalidTemplates() {
+
+	options := &eMailOptions{}
+	suite.NotNil(options.WithSubjectTemplate("{{.MonthName"))
+	suite.NotNil(options.WithMessageTemplate("{{unknown}}"))
+	suite.NotNil(options.WithTextMessageTemplate("{{end}}"))
+}
+
+func (suite *EMailTemplateTestSuite) TestTemplateFuncs() {
+
+	funcs := templateFuncsOf(nil)
+	suite.Equal("2022-01-10", funcs["formatDate"].(func(Date) string)(Date{Year: 2022, Month: 1, Day: 10}))
+	suite.Equal("Monday", funcs["weekday"].(func(Date) string)(Date{Year: 2022, Month: 1, Day: 10}))
+
+	funcs = templateFuncsOf(monthlyReportForTest())
+	suite.Equal("10.01.2022", funcs["formatDate"].(func(Date) string)(Date{Year: 2022, Month: 1, Day: 10}))
+	suite.Equal("Montag", funcs["weekday"].(func(Date) string)(Date{Year: 2022, Month: 1, Day: 10}))
+}
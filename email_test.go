@@ -1,9 +1,18 @@
 package timetracker
 
 import (
-	"github.com/stretchr/testify/suite"
+	"encoding/base64"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
 	"os"
+	"strings"
 	"testing"
+
+	"github.com/stretchr/testify/suite"
 )
 
 type EMailTestSuite struct {
@@ -37,6 +46,128 @@ func (suite *EMailTestSuite) TestSendEMail() {
 	suite.Nil(publisher.Send(fileContent, filename))
 }
 
+func (suite *EMailTestSuite) TestRawEMail() {
+
+	options := &eMailOptions{}
+	options.WithCC("Max Müller <max@example.com>")
+	options.WithBCC("archive@example.com")
+	eMail, err := options.newEMail("reports@example.com", "jane@example.com, John <john@example.com>",
+		"Arbeitszeitbericht März", "<html><h1>Bericht</h1><p>Grüße &amp; viel Spaß</p></html>", "", nil)
+	suite.Nil(err)
+	suite.Equal([]string{"jane@example.com", "john@example.com", "max@example.com", "archive@example.com"}, eMail.recipients())
+
+	rawEMail, err := rawEMail(eMail, []byte("date,working time\n"), "report_202203.csv")
+	suite.Nil(err)
+	message, err := mail.ReadMessage(strings.NewReader(*rawEMail))
+	suite.Nil(err)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(message.Header.Get("Subject"))
+	suite.Nil(err)
+	suite.Equal("Arbeitszeitbericht März", subject)
+	suite.NotContains(message.Header.Get("Subject"), "ä")
+	suite.Equal("<jane@example.com>, \"John\" <john@example.com>", message.Header.Get("To"))
+	suite.Contains(message.Header.Get("Cc"), "max@example.com")
+	suite.NotContains(*rawEMail, "archive@example.com")
+
+	mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	suite.Nil(err)
+	suite.Equal("multipart/mixed", mediaType)
+	reader := multipart.NewReader(message.Body, params["boundary"])
+
+	alternativePart, err := reader.NextPart()
+	suite.Nil(err)
+	mediaType, params, err = mime.ParseMediaType(alternativePart.Header.Get("Content-Type"))
+	suite.Nil(err)
+	suite.Equal("multipart/alternative", mediaType)
+	alternativeReader := multipart.NewReader(alternativePart, params["boundary"])
+	textPart, err := alternativeReader.NextRawPart()
+	suite.Nil(err)
+	suite.Equal("text/plain; charset=utf-8", textPart.Header.Get("Content-Type"))
+	suite.Equal("quoted-printable", textPart.Header.Get("Content-Transfer-Encoding"))
+	text, err := io.ReadAll(quotedprintable.NewReader(textPart))
+	suite.Nil(err)
+	suite.Equal("Bericht\r\nGrüße & viel Spaß", string(text))
+	htmlPart, err := alternativeReader.NextRawPart()
+	suite.Nil(err)
+	suite.Equal("text/html; charset=utf-8", htmlPart.Header.Get("Content-Type"))
+	htmlContent, err := io.ReadAll(quotedprintable.NewReader(htmlPart))
+	suite.Nil(err)
+	suite.Equal("<html><h1>Bericht</h1><p>Grüße &amp; viel Spaß</p></html>", string(htmlContent))
+
+	attachmentPart, err := reader.NextRawPart()
+	suite.Nil(err)
+	suite.Equal("report_202203.csv", attachmentPart.FileName())
+	mediaType, _, err = mime.ParseMediaType(attachmentPart.Header.Get("Content-Type"))
+	suite.Nil(err)
+	suite.Equal("text/csv", mediaType)
+	attachment, err := io.ReadAll(base64.NewDecoder(base64.StdEncoding, attachmentPart))
+	suite.Nil(err)
+	suite.Equal("date,working time\n", string(attachment))
+}
+
+func (suite *EMailTestSuite) TestReportLink() {
+
+	options := &eMailOptions{}
+	reportLink, err := options.reportLinkOf([]byte("large report"), "report_2022.xlsx")
+	suite.Nil(err)
+	suite.Equal("", reportLink)
+
+	options.WithReportLink(&reportLinkForTest{url: "https://example.com/report_2022.xlsx?X-Amz-Signature=abc&X-Amz-Expires=3600"}, 5)
+	reportLink, err = options.reportLinkOf([]byte("small"), "report_2022.xlsx")
+	suite.Nil(err)
+	suite.Equal("", reportLink)
+	reportLink, err = options.reportLinkOf([]byte("large report"), "report_2022.xlsx")
+	suite.Nil(err)
+	suite.Equal("https://example.com/report_2022.xlsx?X-Amz-Signature=abc&X-Amz-Expires=3600", reportLink)
+
+	eMail, err := options.newEMail("reports@example.com", "jane@example.com", "Report", "<p>Your report</p>", reportLink, nil)
+	suite.Nil(err)
+	suite.Equal("<p>Your report</p><p><a href=\"https://example.com/report_2022.xlsx?X-Amz-Signature=abc&amp;X-Amz-Expires=3600\">Open Report</a></p>", eMail.message)
+	suite.Equal("Your report\n\nOpen Report: "+reportLink, eMail.textMessage)
+
+	rawEMail, err := rawEMail(eMail, []byte("large report"), "report_2022.xlsx")
+	suite.Nil(err)
+	message, err := mail.ReadMessage(strings.NewReader(*rawEMail))
+	suite.Nil(err)
+	_, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	suite.Nil(err)
+	reader := multipart.NewReader(message.Body, params["boundary"])
+	_, err = reader.NextPart()
+	suite.Nil(err)
+	_, err = reader.NextPart()
+	suite.Equal(io.EOF, err)
+
+	options.WithReportLink(&reportLinkForTest{err: errors.New("No credentials")}, 0)
+	_, err = options.reportLinkOf([]byte("small"), "report_2022.xlsx")
+	suite.NotNil(err)
+}
+
+func (suite *EMailTestSuite) TestInvalidAddresses() {
+
+	options := &eMailOptions{}
+	_, err := options.newEMail("invalid", "jane@example.com", "Report", "", "", nil)
+	suite.NotNil(err)
+	_, err = options.newEMail("reports@example.com", "", "Report", "", "", nil)
+	suite.NotNil(err)
+	options.WithCC("invalid")
+	_, err = options.newEMail("reports@example.com", "jane@example.com", "Report", "", "", nil)
+	suite.NotNil(err)
+}
+
+func (suite *EMailTestSuite) TestContentTypes() {
+	suite.Equal("application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", contentTypeOf("report.xlsx"))
+	suite.Equal("application/pdf", contentTypeOf("report.PDF"))
+	suite.Equal("application/json", contentTypeOf("report.json"))
+	suite.Equal("text/calendar", contentTypeOf("report.ics"))
+	suite.Equal("application/octet-stream", contentTypeOf("report"))
+}
+
+func (suite *EMailTestSuite) TestBase64Lines() {
+	lines := strings.Split(string(base64Lines(make([]byte, 100))), "\r\n")
+	suite.Len(lines, 2)
+	suite.Len(lines[0], 76)
+}
+
 func (suite *EMailTestSuite) emailSourceForTest() string {
 	source, ok := os.LookupEnv("HOB_EMAIL_SOURCE")
 	suite.True(ok)
@@ -3,6 +3,7 @@ package timetracker
 import (
 	"bytes"
 	"fmt"
+	"strconv"
 	"time"
 
 	log "github.com/tommzn/go-log"
@@ -12,28 +13,17 @@ import (
 // NewExcelReportFormatter returns a new formatter to generate an excel file for a report.
 func NewExcelReportFormatter(logger log.Logger) *ExcelReportFormatter {
 	return &ExcelReportFormatter{
-		dateFormat: "2006-01-02",
-		timeFormat: "15:04",
-		logger:     logger,
+		reportFormat: newReportFormat(logger),
 	}
 }
 
 // ExcelReportFormatter will generate Excel files for reports
 type ExcelReportFormatter struct {
 
-	// Holidays is a list of public holidays.
-	// In case a day matches a date from this list it will be formatted with a specific backgound color.
+	// ReportFormat contains date/time formats, timezone and holidays.
+	// In case a day matches a date from list of holidays it will be formatted with a specific backgound color.
 	// see holidayStyleId for format infos.
-	holidays map[Date]Holiday
-
-	// dateFormat defnines the format a day should be printed in the outut.
-	dateFormat string
-
-	// TimeFormat defines output format for timestamps of time tracking records.
-	timeFormat string
-
-	// Timezone is used to convert timestamps from time tracking records, captured in UTC, to local time.
-	timezone *time.Location
+	reportFormat
 
 	// HeadlineStyleId, style generated for header columns.
 	// Bold, with bottom border
@@ -51,15 +41,21 @@ type ExcelReportFormatter struct {
 	// Light green background
 	holidayStyleId int
 
-	// IllnessStyleId, style for days of illness.
-	// Orange background
-	illnessStyleId int
+	// RecordTypeStyleIds, styles for days of illness, vacation and all other non working day types.
+	// See recordTypeFormats for background colors.
+	recordTypeStyleIds map[RecordType]int
 
-	// VacationStyleId, style for days of vacations.
-	// Green background
-	vacationStyleId int
+	// Columns, and their order, in monthly sheets. See defaultExcelColumns if not set.
+	columns []ExcelColumn
 
-	logger log.Logger
+	// SummarySheet defines if a sheet with charts should be added to monthly reports.
+	summarySheet bool
+
+	// Styles contains definitions of all styles created for current Excel file, used to derive styles with number formats.
+	styles map[int]*excelize.Style
+
+	// NumberFormatStyleIds contains styles with a number format for durations or timestamps, derived from styles above.
+	numberFormatStyleIds map[string]int
 }
 
 // FileExtension reurns file extension for Exce files: xlsx.
@@ -67,11 +63,6 @@ func (formatter *ExcelReportFormatter) FileExtension() string {
 	return ".xlsx"
 }
 
-// WithHolidays will assign give list of holidays for output formatting.
-func (formatter *ExcelReportFormatter) WithHolidays(holidays []Holiday) {
-	formatter.holidays = asHolidayMap(holidays)
-}
-
 // WriteMonthlyReportToFile will generate a report outout an writes it to given file.
 func (formatter *ExcelReportFormatter) WriteMonthlyReportToFile(report *MonthlyReport, filename string) error {
 
@@ -94,51 +85,69 @@ func (formatter *ExcelReportFormatter) WriteMonthlyReportToBuffer(report *Monthl
 // GenerateOutput writes entire report content, including all styles, to an excel file.
 func (formatter *ExcelReportFormatter) generateOutput(report *MonthlyReport) (*excelize.File, error) {
 
-	formatter.determineDateFormat(report)
-	formatter.determineTimezone(report)
-	days := generateIndexMap(report.Days)
-
 	sheetName := fmt.Sprintf("%04d-%02d", report.Year, report.Month)
 	xls := newExcelFile(sheetName)
 	if err := formatter.createStyles(xls); err != nil {
 		return nil, err
 	}
-
-	writeHeader(xls, sheetName)
-	if err := xls.SetCellStyle(sheetName, getCellId("A", 1), getCellId("F", 1), formatter.headlineStyleId); err != nil {
+	if err := formatter.writeMonthlySheet(xls, sheetName, report); err != nil {
+		return nil, err
+	}
+	if formatter.summarySheet {
+		if err := formatter.writeSummarySheet(xls, report); err != nil {
+			return nil, err
+		}
+	}
+	if err := formatter.writeEventsSheet(xls, formatter.message(msgEvents), []string{sheetName}, []*MonthlyReport{report}); err != nil {
 		return nil, err
 	}
+	return xls, nil
+}
 
-	calendarDay := time.Date(report.Year, time.Month(report.Month), 1, 0, 0, 0, 0, time.UTC)
-	lastDayOfMonth := calendarDay.AddDate(0, 1, -1)
+// WriteMonthlySheet writes all days of given report to a sheet with passed name.
+// Column widths are derived from length of all values in a column.
+func (formatter *ExcelReportFormatter) writeMonthlySheet(xls *excelize.File, sheetName string, report *MonthlyReport) error {
 
-	row := 2
-	for isDayBeforeOrEqual(calendarDay, lastDayOfMonth) {
+	formatter.applyLocale(report)
+	columns := formatter.columnDefinitions(report)
+	lastColumn := columnNameOf(len(columns) - 1)
 
-		date := asDate(calendarDay)
-		if day, ok := days[date]; ok {
-			formatter.appendRowForDay(day, xls, sheetName, row)
-		} else {
-			formatter.appendRowForDay(emptyDay(date), xls, sheetName, row)
+	writeHeader(xls, sheetName, columns)
+	if err := xls.SetCellStyle(sheetName, getCellId("A", 1), getCellId(lastColumn, 1), formatter.headlineStyleId); err != nil {
+		return err
+	}
+
+	row := 2
+	var err error
+	var lastDay Day
+	forEachDayOfMonth(report, func(day Day) {
+		if err == nil {
+			err = formatter.appendRowForDay(day, xls, sheetName, row, columns)
 		}
+		lastDay = day
 		row++
-		calendarDay = nextDay(calendarDay)
+	})
+	if err != nil {
+		return err
 	}
 
-	if err := xls.SetCellStyle(sheetName, getCellId("A", row-1), getCellId("F", row-1), formatter.daysBottomStyleId); err != nil {
-		return nil, err
+	if err := formatter.styleRow(xls, sheetName, row-1, lastDay, columns, formatter.daysBottomStyleId); err != nil {
+		return err
 	}
-	writeSummary(xls, sheetName, row, report.TotalWorkingTime)
-	xls.SetColWidth(sheetName, "A", "E", 12)
-	xls.SetColWidth(sheetName, "F", "F", 30)
-	return xls, nil
+	if err := formatter.writeSummary(xls, sheetName, row, report, columns); err != nil {
+		return err
+	}
+	setColumnWidths(xls, sheetName, columns)
+	return nil
 }
 
 // CreateStyles generates style ids for all styles used in a report.
 func (formatter *ExcelReportFormatter) createStyles(xls *excelize.File) error {
 
 	var err error
-	formatter.headlineStyleId, err = xls.NewStyle(&excelize.Style{
+	formatter.styles = map[int]*excelize.Style{0: &excelize.Style{}}
+	formatter.numberFormatStyleIds = make(map[string]int)
+	formatter.headlineStyleId, err = formatter.newStyle(xls, &excelize.Style{
 		Border: []excelize.Border{
 			{Type: "bottom", Color: "000000", Style: 2},
 			{Type: "left", Color: "FFFFFF", Style: 0},
@@ -151,7 +160,7 @@ func (formatter *ExcelReportFormatter) createStyles(xls *excelize.File) error {
 	if err != nil {
 		return err
 	}
-	formatter.daysBottomStyleId, err = xls.NewStyle(&excelize.Style{
+	formatter.daysBottomStyleId, err = formatter.newStyle(xls, &excelize.Style{
 		Border: []excelize.Border{
 			{Type: "bottom", Color: "000000", Style: 2},
 			{Type: "left", Color: "FFFFFF", Style: 0},
@@ -162,31 +171,60 @@ func (formatter *ExcelReportFormatter) createStyles(xls *excelize.File) error {
 		return err
 	}
 
-	formatter.weekendStyleId, err = xls.NewStyle(&excelize.Style{
+	formatter.weekendStyleId, err = formatter.newStyle(xls, &excelize.Style{
 		Fill: excelize.Fill{Type: "pattern", Color: []string{"#FEC7CE"}, Pattern: 1},
 	})
 	if err != nil {
 		return err
 	}
 
-	formatter.holidayStyleId, err = xls.NewStyle(&excelize.Style{
+	formatter.holidayStyleId, err = formatter.newStyle(xls, &excelize.Style{
 		Fill: excelize.Fill{Type: "pattern", Color: []string{"#FED7DE"}, Pattern: 1},
 	})
 	if err != nil {
 		return err
 	}
 
-	formatter.illnessStyleId, err = xls.NewStyle(&excelize.Style{
-		Fill: excelize.Fill{Type: "pattern", Color: []string{"#ff6600"}, Pattern: 1},
-	})
-	if err != nil {
-		return err
+	formatter.recordTypeStyleIds = make(map[RecordType]int)
+	for recordType, recordTypeFormat := range recordTypeFormats() {
+		formatter.recordTypeStyleIds[recordType], err = formatter.newStyle(xls, &excelize.Style{
+			Fill: excelize.Fill{Type: "pattern", Color: []string{recordTypeFormat.color}, Pattern: 1},
+		})
+		if err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	formatter.vacationStyleId, err = xls.NewStyle(&excelize.Style{
-		Fill: excelize.Fill{Type: "pattern", Color: []string{"#669900"}, Pattern: 1},
-	})
-	return err
+// NewStyle creates a new style in given Excel file and keeps its definition to derive styles with number formats.
+func (formatter *ExcelReportFormatter) newStyle(xls *excelize.File, style *excelize.Style) (int, error) {
+	styleId, err := xls.NewStyle(style)
+	if err == nil {
+		formatter.styles[styleId] = style
+	}
+	return styleId, err
+}
+
+// NumberFormatStyle returns a style with same format as given style and passed number format.
+// Styles are created once for each Excel file.
+func (formatter *ExcelReportFormatter) numberFormatStyle(xls *excelize.File, styleId int, numberFormat string) (int, error) {
+
+	key := fmt.Sprintf("%d|%s", styleId, numberFormat)
+	if numberFormatStyleId, ok := formatter.numberFormatStyleIds[key]; ok {
+		return numberFormatStyleId, nil
+	}
+	style := excelize.Style{}
+	if baseStyle, ok := formatter.styles[styleId]; ok {
+		style = *baseStyle
+	}
+	style.CustomNumFmt = &numberFormat
+	numberFormatStyleId, err := xls.NewStyle(&style)
+	if err != nil {
+		return 0, err
+	}
+	formatter.numberFormatStyleIds[key] = numberFormatStyleId
+	return numberFormatStyleId, nil
 }
 
 // NewExcelFile creates a new, empty Excel file with one sheet using passed sheet name.
@@ -206,130 +244,129 @@ func newExcelFile(sheetName string) *excelize.File {
 }
 
 // WriteHeader adds headline columns at first row of givem Excel file.
-func writeHeader(xls *excelize.File, sheetName string) {
-	xls.SetCellValue(sheetName, getCellId("A", 1), "Date")
-	xls.SetCellValue(sheetName, getCellId("B", 1), "Start")
-	xls.SetCellValue(sheetName, getCellId("C", 1), "End")
-	xls.SetCellValue(sheetName, getCellId("D", 1), "WorkingTime")
-	xls.SetCellValue(sheetName, getCellId("E", 1), "BreakTime")
-	xls.SetCellValue(sheetName, getCellId("F", 1), "Comment")
-}
-
-// WriteSummary appends total working time at given row.
-func writeSummary(xls *excelize.File, sheetName string, row int, totalWorkingTime time.Duration) {
-	xls.SetCellValue(sheetName, getCellId("D", row), formatDuration(totalWorkingTime))
+func writeHeader(xls *excelize.File, sheetName string, columns []*excelColumnDefinition) {
+	for idx, column := range columns {
+		xls.SetCellValue(sheetName, getCellId(columnNameOf(idx), 1), column.header)
+	}
 }
 
-// AppendRowForDay will write values for a single day to the Excel file.
-// This will apply all required styles or weekends or holidayys as well.
-func (formatter *ExcelReportFormatter) appendRowForDay(day Day, xls *excelize.File, sheetName string, row int) {
+// WriteSummary appends sums of all days, e.g. total working time, at given row. If there's a target working time
+// overtime is added at next row, calculated by a formula as well.
+func (formatter *ExcelReportFormatter) writeSummary(xls *excelize.File, sheetName string, row int, report *MonthlyReport, columns []*excelColumnDefinition) error {
 
-	date := formatter.atTimezone(day.Date.AsTime())
-	xls.SetCellValue(sheetName, getCellId("A", row), date.Format(formatter.dateFormat))
-	if len(day.Events) > 0 {
-		xls.SetCellValue(sheetName, getCellId("B", row), formatter.formatTime(day.Events[0].Timestamp))
-	}
-	if len(day.Events) > 1 {
-		xls.SetCellValue(sheetName, getCellId("C", row), formatter.formatTime(day.Events[len(day.Events)-1].Timestamp))
+	durationStyleId, err := formatter.numberFormatStyle(xls, 0, durationNumberFormat)
+	if err != nil {
+		return err
 	}
-	xls.SetCellValue(sheetName, getCellId("D", row), formatDuration(day.WorkingTime))
-	xls.SetCellValue(sheetName, getCellId("E", row), formatDuration(day.BreakTime))
-
-	if day.Type == VACATION {
-		xls.SetCellStyle(sheetName, getCellId("A", row), getCellId("F", row), formatter.vacationStyleId)
-		xls.SetCellValue(sheetName, getCellId("F", row), "Vacation")
+	for idx, column := range columns {
+		if column.sum {
+			columnName := columnNameOf(idx)
+			cell := getCellId(columnName, row)
+			if err := xls.SetCellFormula(sheetName, cell, fmt.Sprintf("SUM(%s:%s)", getCellId(columnName, 2), getCellId(columnName, row-1))); err != nil {
+				return err
+			}
+			xls.SetCellStyle(sheetName, cell, cell, durationStyleId)
+		}
 	}
-
-	if day.Type == ILLNESS {
-		xls.SetCellStyle(sheetName, getCellId("A", row), getCellId("F", row), formatter.illnessStyleId)
-		xls.SetCellValue(sheetName, getCellId("F", row), "Illness")
+	if !columns[0].sum {
+		xls.SetCellValue(sheetName, getCellId("A", row), formatter.message(msgTotal))
 	}
 
-	if isWeekend(day.Date.AsTime()) {
-		xls.SetCellStyle(sheetName, getCellId("A", row), getCellId("F", row), formatter.weekendStyleId)
-	}
-	if holiday, ok := formatter.holidays[day.Date]; ok {
-		xls.SetCellStyle(sheetName, getCellId("A", row), getCellId("F", row), formatter.holidayStyleId)
-		xls.SetCellValue(sheetName, getCellId("F", row), holiday.Description)
+	nextRow := row + 1
+	workingTimeColumn, hasWorkingTime := columnNameOfDefinition(columns, COLUMN_WORKING_TIME)
+	if report.TargetWorkingTime != 0 && hasWorkingTime && workingTimeColumn != "A" {
+		targetWorkingTime := strconv.FormatFloat(excelDuration(report.TargetWorkingTime), 'f', -1, 64)
+		if targetTimeColumn, hasTargetTime := columnNameOfDefinition(columns, COLUMN_TARGET_TIME); hasTargetTime {
+			targetWorkingTime = getCellId(targetTimeColumn, row)
+		}
+		cell := getCellId(workingTimeColumn, nextRow)
+		xls.SetCellValue(sheetName, getCellId("A", nextRow), formatter.message(msgOvertime))
+		if err := xls.SetCellFormula(sheetName, cell, getCellId(workingTimeColumn, row)+"-"+targetWorkingTime); err != nil {
+			return err
+		}
+		if err := xls.SetCellStyle(sheetName, cell, cell, durationStyleId); err != nil {
+			return err
+		}
+		nextRow++
 	}
-}
 
-// AtTimezone returns passed time in a timezone defined for this formatter.
-func (formatter *ExcelReportFormatter) atTimezone(t time.Time) time.Time {
-	if formatter.timezone != nil {
-		return t.In(formatter.timezone)
+	for _, location := range formatter.workLocationDaysOf(report.WorkLocationDays) {
+		xls.SetCellValue(sheetName, getCellId("A", nextRow), location.label)
+		xls.SetCellValue(sheetName, getCellId("B", nextRow), location.days)
+		nextRow++
 	}
-	return t
+	return nil
 }
 
-// GetCellId helper to generate a cell id by goven column and row index.
-func getCellId(column string, row int) string {
-	return fmt.Sprintf("%s%d", column, row)
-}
+// AppendRowForDay will write values for a single day to the Excel file.
+// This will apply all required styles or weekends or holidayys as well.
+// Durations are written as Excel time values, timestamps as Excel date time values.
+func (formatter *ExcelReportFormatter) appendRowForDay(day Day, xls *excelize.File, sheetName string, row int, columns []*excelColumnDefinition) error {
 
-// DetermineDateFormat will apply date format if it has been defined in report locale.
-// Default format is "2006-01-02".
-func (formatter *ExcelReportFormatter) determineDateFormat(report *MonthlyReport) {
-	if report.Location.DateFormat != nil {
-		formatter.dateFormat = *report.Location.DateFormat
+	styleId := 0
+	if _, ok := recordTypeFormats()[day.Type]; ok {
+		styleId = formatter.recordTypeStyleIds[day.Type]
+	}
+	if isWeekend(day.Date.AsTime()) {
+		styleId = formatter.weekendStyleId
+	}
+	if _, ok := formatter.holidays[day.Date]; ok {
+		styleId = formatter.holidayStyleId
+	}
+	if err := formatter.styleRow(xls, sheetName, row, day, columns, styleId); err != nil {
+		return err
 	}
-}
 
-// DetermineTimezone will assign a timezone to a formatter it it has been defined in report local.
-func (formatter *ExcelReportFormatter) determineTimezone(report *MonthlyReport) {
-
-	formatter.logger.Debug("Report timezone: ", report.Location.Timezone)
-	if report.Location.Timezone != nil {
-		if location, err := time.LoadLocation(*report.Location.Timezone); err == nil {
-			formatter.logger.Debugf("Time location: %+v", location)
-			formatter.timezone = location
-			return
-		} else {
-			formatter.logger.Error("Unable to load location, reason: ", err)
+	for idx, column := range columns {
+		cell := getCellId(columnNameOf(idx), row)
+		value := column.value(day)
+		if width := excelCellWidth(value); width > column.width {
+			column.width = width
+		}
+		if column.formula != nil {
+			if err := xls.SetCellFormula(sheetName, cell, column.formula(row)); err != nil {
+				return err
+			}
+			continue
+		}
+		switch typedValue := value.(type) {
+		case time.Duration:
+			xls.SetCellValue(sheetName, cell, excelDuration(typedValue))
+		case time.Time:
+			xls.SetCellValue(sheetName, cell, typedValue)
+		case string:
+			if typedValue != "" {
+				xls.SetCellValue(sheetName, cell, typedValue)
+			}
 		}
 	}
-	formatter.timezone = nil
+	return nil
 }
 
-// FormatTime applies default time format to given timestamp.
-func (formatter *ExcelReportFormatter) formatTime(t time.Time) string {
-	return formatter.atTimezone(t).Format(formatter.timeFormat)
-}
+// StyleRow applies given style to all columns of a row. Cells with durations or timestamps
+// get a style with same format and a number format for time values.
+func (formatter *ExcelReportFormatter) styleRow(xls *excelize.File, sheetName string, row int, day Day, columns []*excelColumnDefinition, styleId int) error {
 
-// FormatDuration returns string representation of given duration in format HH:MM.
-func formatDuration(d time.Duration) string {
-	d = d.Round(time.Minute)
-	h := d / time.Hour
-	d -= h * time.Hour
-	m := d / time.Minute
-	return fmt.Sprintf("%02d:%02d", h, m)
-}
-
-// GenerateIndexMap creates a map where date of a day in used as index.
-func generateIndexMap(days []Day) map[Date]Day {
-	daysMao := make(map[Date]Day)
-	for _, day := range days {
-		daysMao[day.Date] = day
+	lastColumn := columnNameOf(len(columns) - 1)
+	if err := xls.SetCellStyle(sheetName, getCellId("A", row), getCellId(lastColumn, row), styleId); err != nil {
+		return err
 	}
-	return daysMao
-}
-
-// EmptyDay returns a working day without time tracking events an no working or break time.
-func emptyDay(date Date) Day {
-	return Day{
-		Date:        date,
-		Type:        WORKDAY,
-		WorkingTime: time.Duration(0),
-		BreakTime:   time.Duration(0),
-		Events:      []TimeTrackingRecord{},
+	for idx, column := range columns {
+		if numberFormat := numberFormatOf(column.value(day)); numberFormat != "" {
+			numberFormatStyleId, err := formatter.numberFormatStyle(xls, styleId, numberFormat)
+			if err != nil {
+				return err
+			}
+			cell := getCellId(columnNameOf(idx), row)
+			if err := xls.SetCellStyle(sheetName, cell, cell, numberFormatStyleId); err != nil {
+				return err
+			}
+		}
 	}
+	return nil
 }
 
-// AsHolidayMap generates a map with date index for passed lost pf holidays.
-func asHolidayMap(holidays []Holiday) map[Date]Holiday {
-	holidayMap := make(map[Date]Holiday)
-	for _, holiday := range holidays {
-		holidayMap[holiday.Date] = holiday
-	}
-	return holidayMap
+// GetCellId helper to generate a cell id by goven column and row index.
+func getCellId(column string, row int) string {
+	return fmt.Sprintf("%s%d", column, row)
 }
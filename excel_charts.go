@@ -0,0 +1,182 @@
+package timetracker
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ExcelChart defines format of a chart in summary sheet, see excelize.AddChart for details.
+type excelChart struct {
+	Type      string               `json:"type"`
+	Series    []excelChartSeries   `json:"series"`
+	Title     excelChartTitle      `json:"title"`
+	Legend    excelChartLegend     `json:"legend"`
+	Dimension excelChartDimension  `json:"dimension"`
+	Plotarea  excelChartPlotarea   `json:"plotarea"`
+	YAxis     *excelChartValueAxis `json:"y_axis,omitempty"`
+}
+
+// ExcelChartSeries is a single data series of a chart.
+type excelChartSeries struct {
+	Name       string `json:"name"`
+	Categories string `json:"categories"`
+	Values     string `json:"values"`
+}
+
+// ExcelChartTitle is the title of a chart.
+type excelChartTitle struct {
+	Name string `json:"name"`
+}
+
+// ExcelChartLegend defines position of a chart legend.
+type excelChartLegend struct {
+	Position string `json:"position"`
+}
+
+// ExcelChartDimension defines width and height of a chart in pixel.
+type excelChartDimension struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// ExcelChartPlotarea defines labels shown in a chart.
+type excelChartPlotarea struct {
+	ShowPercent bool `json:"show_percent"`
+}
+
+// ExcelChartValueAxis defines number format of a value axis.
+type excelChartValueAxis struct {
+	NumFormat string `json:"num_format"`
+}
+
+// WithSummarySheet adds a sheet with charts to monthly reports: daily working time against target,
+// types of all days and cumulative overtime.
+func (formatter *ExcelReportFormatter) WithSummarySheet() {
+	formatter.summarySheet = true
+}
+
+// WriteSummarySheet adds a sheet with a data table for daily working time, target working time and cumulative
+// overtime, a table of day types and charts for both tables. All times are written as decimal hours.
+func (formatter *ExcelReportFormatter) writeSummarySheet(xls *excelize.File, report *MonthlyReport) error {
+
+	formatter.applyLocale(report)
+	sheetName := formatter.message(msgSummary)
+	xls.NewSheet(sheetName)
+
+	headers := []messageKey{msgDate, msgWorkingTime, msgTargetTime, msgOvertime}
+	for idx, header := range headers {
+		xls.SetCellValue(sheetName, getCellId(columnNameOf(idx), 1), formatter.message(header))
+	}
+	xls.SetCellValue(sheetName, getCellId("F", 1), formatter.message(msgType))
+	xls.SetCellValue(sheetName, getCellId("G", 1), formatter.message(msgDays))
+	if err := xls.SetCellStyle(sheetName, getCellId("A", 1), getCellId("G", 1), formatter.headlineStyleId); err != nil {
+		return err
+	}
+	hoursStyleId, err := formatter.numberFormatStyle(xls, 0, "0.00")
+	if err != nil {
+		return err
+	}
+
+	row := 2
+	overtime := 0.0
+	dayTypes := []messageKey{}
+	dayTypeCount := make(map[messageKey]int)
+	forEachDayOfMonth(report, func(day Day) {
+		overtime += hoursOf(day.WorkingTime) - hoursOf(day.TargetTime)
+		xls.SetCellValue(sheetName, getCellId("A", row), formatter.formatDate(day.Date))
+		xls.SetCellValue(sheetName, getCellId("B", row), hoursOf(day.WorkingTime))
+		xls.SetCellValue(sheetName, getCellId("C", row), hoursOf(day.TargetTime))
+		xls.SetCellValue(sheetName, getCellId("D", row), overtime)
+		row++
+
+		if dayType, ok := formatter.dayTypeOf(day); ok {
+			if _, exists := dayTypeCount[dayType]; !exists {
+				dayTypes = append(dayTypes, dayType)
+			}
+			dayTypeCount[dayType]++
+		}
+	})
+	lastRow := row - 1
+	if err := xls.SetCellStyle(sheetName, getCellId("B", 2), getCellId("D", lastRow), hoursStyleId); err != nil {
+		return err
+	}
+	for idx, dayType := range dayTypes {
+		xls.SetCellValue(sheetName, getCellId("F", idx+2), formatter.message(dayType))
+		xls.SetCellValue(sheetName, getCellId("G", idx+2), dayTypeCount[dayType])
+	}
+	xls.SetColWidth(sheetName, "A", "D", 14)
+	xls.SetColWidth(sheetName, "F", "G", 18)
+
+	dates := chartRange(sheetName, "A", 2, lastRow)
+	charts := []struct {
+		cell  string
+		chart excelChart
+	}{
+		{cell: "I1", chart: excelChart{
+			Type: excelize.Col,
+			Series: []excelChartSeries{
+				{Name: chartRange(sheetName, "B", 1, 1), Categories: dates, Values: chartRange(sheetName, "B", 2, lastRow)},
+				{Name: chartRange(sheetName, "C", 1, 1), Categories: dates, Values: chartRange(sheetName, "C", 2, lastRow)},
+			},
+			Title: excelChartTitle{Name: formatter.message(msgWorkingTime)},
+			YAxis: &excelChartValueAxis{NumFormat: "0.0"},
+		}},
+		{cell: "I17", chart: excelChart{
+			Type: excelize.Pie,
+			Series: []excelChartSeries{
+				{Name: chartRange(sheetName, "G", 1, 1), Categories: chartRange(sheetName, "F", 2, len(dayTypes)+1), Values: chartRange(sheetName, "G", 2, len(dayTypes)+1)},
+			},
+			Title:    excelChartTitle{Name: formatter.message(msgDays)},
+			Plotarea: excelChartPlotarea{ShowPercent: true},
+		}},
+		{cell: "I33", chart: excelChart{
+			Type: excelize.Line,
+			Series: []excelChartSeries{
+				{Name: chartRange(sheetName, "D", 1, 1), Categories: dates, Values: chartRange(sheetName, "D", 2, lastRow)},
+			},
+			Title: excelChartTitle{Name: formatter.message(msgOvertime)},
+			YAxis: &excelChartValueAxis{NumFormat: "0.0"},
+		}},
+	}
+	for _, chartAtCell := range charts {
+		chart := chartAtCell.chart
+		chart.Legend = excelChartLegend{Position: "bottom"}
+		chart.Dimension = excelChartDimension{Width: 720, Height: 300}
+		format, err := json.Marshal(chart)
+		if err != nil {
+			return err
+		}
+		if err := xls.AddChart(sheetName, chartAtCell.cell, string(format)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DayTypeOf returns the message key of the type of given day, used in day type chart.
+// Weekends are skipped, holidays are counted as holidays regardless of type of a day.
+func (formatter *ExcelReportFormatter) dayTypeOf(day Day) (messageKey, bool) {
+	if isWeekend(day.Date.AsTime()) {
+		return "", false
+	}
+	if _, ok := formatter.holidays[day.Date]; ok {
+		return msgHoliday, true
+	}
+	if day.Type == WORKDAY {
+		return msgWork, true
+	}
+	return messageKey(day.Type), true
+}
+
+// ChartRange returns an absolute reference to given rows of a column, e.g. 'Summary'!$B$2:$B$32.
+func chartRange(sheetName, column string, firstRow, lastRow int) string {
+	return fmt.Sprintf("'%s'!$%s$%d:$%s$%d", sheetName, column, firstRow, column, lastRow)
+}
+
+// HoursOf returns given duration, rounded to minutes, as decimal hours.
+func hoursOf(d time.Duration) float64 {
+	return d.Round(time.Minute).Minutes() / 60
+}
@@ -0,0 +1,69 @@
+package timetracker
+
+import (
+	"archive/zip"
+	"bytes"
+	"github.com/stretchr/testify/suite"
+	"github.com/xuri/excelize/v2"
+	"strings"
+	"testing"
+	"time"
+)
+
+type ExcelChartsTestSuite struct {
+	suite.Suite
+}
+
+func TestExcelChartsTestSuite(t *testing.T) {
+	suite.Run(t, new(ExcelChartsTestSuite))
+}
+
+func (suite *ExcelChartsTestSuite) TestSummarySheet() {
+
+	formatter := NewExcelReportFormatter(loggerForTest())
+	formatter.WithSummarySheet()
+	formatter.WithHolidays([]Holiday{Holiday{Date: Date{Year: 2022, Month: 1, Day: 6}, Description: "Heilige Drei Könige"}})
+	report := monthlyReportWithAllTypesForTest()
+	report.Days[4].TargetTime = 8 * time.Hour
+
+	buf, err := formatter.WriteMonthlyReportToBuffer(report)
+	suite.Nil(err)
+	suite.Equal(3, suite.numberOfCharts(buf.Bytes()))
+	xls, err := excelize.OpenReader(buf)
+	suite.Nil(err)
+	suite.Equal([]string{"2022-01", "Zusammenfassung", "Ereignisse"}, xls.GetSheetList())
+
+	rows, err := xls.GetRows("Zusammenfassung")
+	suite.Nil(err)
+	suite.Len(rows, 32)
+	suite.Equal([]string{"Datum", "Arbeitszeit", "Sollzeit", "Überstunden", "", "Typ", "Tage"}, rows[0])
+	suite.Equal([]string{"01.01.2022", "8", "0", "8", "", "Arbeit", "12"}, rows[1])
+	suite.Equal([]string{"Feiertag", "1"}, rows[2][5:])
+	suite.Equal([]string{"Krankheit", "1"}, rows[3][5:])
+	suite.Equal([]string{"Urlaub", "2"}, rows[4][5:])
+	suite.Equal([]string{"17.01.2022", "9", "8", "33"}, rows[17][0:4])
+	suite.Equal([]string{"31.01.2022", "0", "0", "33"}, rows[31])
+
+	formatter = NewExcelReportFormatter(loggerForTest())
+	buf, err = formatter.WriteMonthlyReportToBuffer(report)
+	suite.Nil(err)
+	xls, err = excelize.OpenReader(buf)
+	suite.Nil(err)
+	suite.Equal([]string{"2022-01", "Ereignisse"}, xls.GetSheetList())
+}
+
+func (suite *ExcelChartsTestSuite) numberOfCharts(content []byte) int {
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	suite.Nil(err)
+	charts := 0
+	for _, file := range archive.File {
+		if strings.HasPrefix(file.Name, "xl/charts/chart") {
+			charts++
+		}
+	}
+	return charts
+}
+
+func (suite *ExcelChartsTestSuite) TestChartRange() {
+	suite.Equal("'Summary'!$B$2:$B$32", chartRange("Summary", "B", 2, 32))
+}
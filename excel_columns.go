@@ -0,0 +1,357 @@
+package timetracker
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ExcelColumn is a column in monthly sheets of Excel reports.
+type ExcelColumn string
+
+const (
+	// COLUMN_DATE is the date of a day.
+	COLUMN_DATE ExcelColumn = "date"
+
+	// COLUMN_WEEKDAY is the name of a day of the week.
+	COLUMN_WEEKDAY ExcelColumn = "weekday"
+
+	// COLUMN_START is the first event of a day.
+	COLUMN_START ExcelColumn = "start"
+
+	// COLUMN_END is the last event of a day.
+	COLUMN_END ExcelColumn = "end"
+
+	// COLUMN_EVENT_PAIRS adds a start and an end column for each pair of working events.
+	// Number of columns depends on max number of event pairs per day in a report.
+	COLUMN_EVENT_PAIRS ExcelColumn = "event_pairs"
+
+	// COLUMN_WORKING_TIME is the working time of a day, excluding breaks.
+	COLUMN_WORKING_TIME ExcelColumn = "working_time"
+
+	// COLUMN_BREAK_TIME is the break time of a day.
+	COLUMN_BREAK_TIME ExcelColumn = "break_time"
+
+	// COLUMN_BREAKS lists all breaks between pairs of working events, e.g. "12:00-12:30".
+	COLUMN_BREAKS ExcelColumn = "breaks"
+
+	// COLUMN_TARGET_TIME is the target working time of a day.
+	COLUMN_TARGET_TIME ExcelColumn = "target_time"
+
+	// COLUMN_DELTA is the difference between working time and target working time.
+	COLUMN_DELTA ExcelColumn = "delta"
+
+	// COLUMN_PROJECT lists all projects of time tracking records of a day.
+	COLUMN_PROJECT ExcelColumn = "project"
+
+	// COLUMN_WORK_LOCATION is the work location of a day.
+	COLUMN_WORK_LOCATION ExcelColumn = "work_location"
+
+	// COLUMN_ESTIMATED marks days with estimated time tracking records.
+	COLUMN_ESTIMATED ExcelColumn = "estimated"
+
+	// COLUMN_COMMENT is a description of a holiday or a label for days of illness, vacation, etc.
+	COLUMN_COMMENT ExcelColumn = "comment"
+)
+
+const (
+	// MinColumnWidth is the min width of a column in monthly sheets.
+	minColumnWidth = 10
+
+	// MaxColumnWidth is the max width of a column in monthly sheets.
+	maxColumnWidth = 60
+)
+
+// DefaultExcelColumns returns columns used if there's no column configuration:
+// date, start, end, working time, break time and comment.
+func defaultExcelColumns() []ExcelColumn {
+	return []ExcelColumn{COLUMN_DATE, COLUMN_START, COLUMN_END, COLUMN_WORKING_TIME, COLUMN_BREAK_TIME, COLUMN_COMMENT}
+}
+
+// ExcelColumnDefinition defines header, values and total of a single column in a monthly sheet.
+type excelColumnDefinition struct {
+
+	// Column is the configured column this definition belongs to.
+	column ExcelColumn
+
+	// Header is the column name in first row.
+	header string
+
+	// Value returns the value of a column for given day. Values can be a string, a duration,
+	// written as Excel time value, or a timestamp, written as Excel date time value.
+	value func(day Day) any
+
+	// Formula returns a formula for a day at given row. Optional, overwrites value.
+	formula func(row int) string
+
+	// Sum defines if summary row contains a sum of all days for this column.
+	sum bool
+
+	// Width is the max length of all values in this column.
+	width int
+}
+
+// WithColumns defines columns, and their order, in monthly sheets. Unknown columns are ignored.
+func (formatter *ExcelReportFormatter) WithColumns(columns ...ExcelColumn) {
+	formatter.columns = columns
+}
+
+// ColumnDefinitions returns definitions for all configured columns.
+// Pairs of working events are expanded to as many columns as necessary for given report.
+// If working time and target working time are part of a sheet, delta is calculated by a formula.
+func (formatter *ExcelReportFormatter) columnDefinitions(report *MonthlyReport) []*excelColumnDefinition {
+
+	columns := formatter.columns
+	if len(columns) == 0 {
+		columns = defaultExcelColumns()
+	}
+
+	definitions := []*excelColumnDefinition{}
+	for _, column := range columns {
+		if column == COLUMN_EVENT_PAIRS {
+			definitions = append(definitions, formatter.eventPairColumns(report)...)
+		} else if definition := formatter.columnDefinition(column); definition != nil {
+			definitions = append(definitions, definition)
+		}
+	}
+	for _, definition := range definitions {
+		definition.width = utf8.RuneCountInString(definition.header)
+	}
+
+	workingTimeColumn, hasWorkingTime := columnNameOfDefinition(definitions, COLUMN_WORKING_TIME)
+	targetTimeColumn, hasTargetTime := columnNameOfDefinition(definitions, COLUMN_TARGET_TIME)
+	deltaColumn, hasDelta := indexOfDefinition(definitions, COLUMN_DELTA)
+	if hasWorkingTime && hasTargetTime && hasDelta {
+		definitions[deltaColumn].formula = func(row int) string {
+			return getCellId(workingTimeColumn, row) + "-" + getCellId(targetTimeColumn, row)
+		}
+	}
+	return definitions
+}
+
+// ColumnDefinition returns definition of given column. Returns nil for unknown columns.
+func (formatter *ExcelReportFormatter) columnDefinition(column ExcelColumn) *excelColumnDefinition {
+
+	definition := &excelColumnDefinition{column: column}
+	switch column {
+	case COLUMN_DATE:
+		definition.header = formatter.message(msgDate)
+		definition.value = func(day Day) any { return formatter.formatDate(day.Date) }
+	case COLUMN_WEEKDAY:
+		definition.header = formatter.message(msgWeekday)
+		definition.value = func(day Day) any { return formatter.messages.weekday(day.Date.AsTime().Weekday()) }
+	case COLUMN_START:
+		definition.header = formatter.message(msgStart)
+		definition.value = func(day Day) any {
+			if len(day.Events) > 0 {
+				return formatter.atTimezone(day.Events[0].Timestamp)
+			}
+			return nil
+		}
+	case COLUMN_END:
+		definition.header = formatter.message(msgEnd)
+		definition.value = func(day Day) any {
+			if len(day.Events) > 1 {
+				return formatter.atTimezone(day.Events[len(day.Events)-1].Timestamp)
+			}
+			return nil
+		}
+	case COLUMN_WORKING_TIME:
+		definition.header = formatter.message(msgWorkingTime)
+		definition.value = func(day Day) any { return day.WorkingTime }
+		definition.sum = true
+	case COLUMN_BREAK_TIME:
+		definition.header = formatter.message(msgBreakTime)
+		definition.value = func(day Day) any { return day.BreakTime }
+	case COLUMN_BREAKS:
+		definition.header = formatter.message(msgBreaks)
+		definition.value = func(day Day) any { return formatter.breaksOf(day) }
+	case COLUMN_TARGET_TIME:
+		definition.header = formatter.message(msgTargetTime)
+		definition.value = func(day Day) any { return day.TargetTime }
+		definition.sum = true
+	case COLUMN_DELTA:
+		definition.header = formatter.message(msgDelta)
+		definition.value = func(day Day) any { return day.WorkingTime - day.TargetTime }
+		definition.sum = true
+	case COLUMN_PROJECT:
+		definition.header = formatter.message(msgProject)
+		definition.value = func(day Day) any { return projectsOf(day) }
+	case COLUMN_WORK_LOCATION:
+		definition.header = formatter.message(msgWorkLocation)
+		definition.value = func(day Day) any {
+			if day.WorkLocation == "" {
+				return ""
+			}
+			return formatter.message(messageKey(day.WorkLocation))
+		}
+	case COLUMN_ESTIMATED:
+		definition.header = formatter.message(msgEstimated)
+		definition.value = func(day Day) any {
+			for _, event := range day.Events {
+				if event.Estimated {
+					return formatter.message(msgYes)
+				}
+			}
+			return ""
+		}
+	case COLUMN_COMMENT:
+		definition.header = formatter.message(msgComment)
+		definition.value = func(day Day) any { return formatter.commentOf(day) }
+	default:
+		return nil
+	}
+	return definition
+}
+
+// EventPairColumns returns a start and an end column for each pair of working events.
+// At least one pair of columns is returned.
+func (formatter *ExcelReportFormatter) eventPairColumns(report *MonthlyReport) []*excelColumnDefinition {
+
+	numberOfPairs := 1
+	for _, day := range report.Days {
+		if pairs := len(eventPairsOf(day)); pairs > numberOfPairs {
+			numberOfPairs = pairs
+		}
+	}
+
+	definitions := []*excelColumnDefinition{}
+	for idx := 0; idx < numberOfPairs; idx++ {
+		pairIdx := idx
+		definitions = append(definitions, &excelColumnDefinition{
+			column: COLUMN_EVENT_PAIRS,
+			header: fmt.Sprintf("%s %d", formatter.message(msgStart), pairIdx+1),
+			value: func(day Day) any {
+				if pairs := eventPairsOf(day); pairIdx < len(pairs) {
+					return formatter.atTimezone(pairs[pairIdx][0].Timestamp)
+				}
+				return nil
+			},
+		})
+		definitions = append(definitions, &excelColumnDefinition{
+			column: COLUMN_EVENT_PAIRS,
+			header: fmt.Sprintf("%s %d", formatter.message(msgEnd), pairIdx+1),
+			value: func(day Day) any {
+				if pairs := eventPairsOf(day); pairIdx < len(pairs) && len(pairs[pairIdx]) > 1 {
+					return formatter.atTimezone(pairs[pairIdx][1].Timestamp)
+				}
+				return nil
+			},
+		})
+	}
+	return definitions
+}
+
+// IndexOfDefinition returns the index of first definition for given column.
+func indexOfDefinition(definitions []*excelColumnDefinition, column ExcelColumn) (int, bool) {
+	for idx, definition := range definitions {
+		if definition.column == column {
+			return idx, true
+		}
+	}
+	return 0, false
+}
+
+// ColumnNameOfDefinition returns the Excel column name, e.g. "D", of first definition for given column.
+func columnNameOfDefinition(definitions []*excelColumnDefinition, column ExcelColumn) (string, bool) {
+	idx, ok := indexOfDefinition(definitions, column)
+	return columnNameOf(idx), ok
+}
+
+// BreaksOf returns all breaks between pairs of working events of a day, e.g. "12:00-12:30, 15:00-15:15".
+func (formatter *ExcelReportFormatter) breaksOf(day Day) string {
+
+	breaks := []string{}
+	pairs := eventPairsOf(day)
+	for idx := 1; idx < len(pairs); idx++ {
+		if len(pairs[idx-1]) > 1 {
+			breaks = append(breaks, formatter.formatTime(pairs[idx-1][1].Timestamp)+"-"+formatter.formatTime(pairs[idx][0].Timestamp))
+		}
+	}
+	return strings.Join(breaks, ", ")
+}
+
+// EventPairsOf splits working events of a day into pairs of start and end events.
+// Last pair contains a start event, only, if there's an odd number of events.
+func eventPairsOf(day Day) [][]TimeTrackingRecord {
+	events := workingEvents(day.Events)
+	if len(events) == 0 {
+		return [][]TimeTrackingRecord{}
+	}
+	return splitTimeTrackingRecords(events, 2)
+}
+
+// ProjectsOf returns all distinct projects of time tracking records of a day.
+func projectsOf(day Day) string {
+
+	projects := []string{}
+	existingProjects := make(map[string]bool)
+	for _, event := range day.Events {
+		if event.Project != "" && !existingProjects[event.Project] {
+			existingProjects[event.Project] = true
+			projects = append(projects, event.Project)
+		}
+	}
+	return strings.Join(projects, ", ")
+}
+
+// SetColumnWidths applies widths of given columns to a sheet, based on max length of their values.
+func setColumnWidths(xls *excelize.File, sheetName string, columns []*excelColumnDefinition) {
+	for idx, column := range columns {
+		width := column.width + 2
+		if width < minColumnWidth {
+			width = minColumnWidth
+		}
+		if width > maxColumnWidth {
+			width = maxColumnWidth
+		}
+		columnName := columnNameOf(idx)
+		xls.SetColWidth(sheetName, columnName, columnName, float64(width))
+	}
+}
+
+// ColumnNameOf returns the name of a column for given zero based index, e.g. "A" for 0.
+func columnNameOf(idx int) string {
+	columnName, _ := excelize.ColumnNumberToName(idx + 1)
+	return columnName
+}
+
+const (
+	// DurationNumberFormat is the Excel number format for durations, hours can exceed 24.
+	durationNumberFormat = "[h]:mm;-[h]:mm"
+
+	// ClockNumberFormat is the Excel number format for timestamps of time tracking records.
+	clockNumberFormat = "hh:mm"
+)
+
+// ExcelDuration converts given duration, rounded to minutes, to an Excel time value, which is a fraction of a day.
+func excelDuration(d time.Duration) float64 {
+	return d.Round(time.Minute).Minutes() / (24 * 60)
+}
+
+// NumberFormatOf returns the Excel number format for given cell value. Empty for all values except durations and timestamps.
+func numberFormatOf(value any) string {
+	switch value.(type) {
+	case time.Duration:
+		return durationNumberFormat
+	case time.Time:
+		return clockNumberFormat
+	}
+	return ""
+}
+
+// ExcelCellWidth returns the length of given cell value in a sheet.
+func excelCellWidth(value any) int {
+	switch typedValue := value.(type) {
+	case time.Duration:
+		return utf8.RuneCountInString(formatDuration(typedValue))
+	case time.Time:
+		return len(clockNumberFormat)
+	case string:
+		return utf8.RuneCountInString(typedValue)
+	}
+	return 0
+}
@@ -0,0 +1,140 @@
+package timetracker
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/xuri/excelize/v2"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type ExcelColumnsTestSuite struct {
+	suite.Suite
+}
+
+func TestExcelColumnsTestSuite(t *testing.T) {
+	suite.Run(t, new(ExcelColumnsTestSuite))
+}
+
+func (suite *ExcelColumnsTestSuite) TestDefaultColumns() {
+
+	xls := suite.generateSheet(NewExcelReportFormatter(loggerForTest()), monthlyReportWithAllTypesForTest())
+
+	rows, err := xls.GetRows("2022-01")
+	suite.Nil(err)
+	suite.Equal([]string{"Datum", "Beginn", "Ende", "Arbeitszeit", "Pause", "Bemerkung"}, rows[0])
+	suite.Equal([]string{"01.01.2022", "09:00", "17:30"}, rows[1][0:3])
+	suite.assertDuration(xls, "D2", 8*time.Hour)
+	suite.assertDuration(xls, "E2", 30*time.Minute)
+	suite.Equal("Summe", rows[32][0])
+	suite.assertFormula(xls, "D33", "SUM(D2:D32)", 41*time.Hour)
+	suite.Len(rows, 33)
+}
+
+func (suite *ExcelColumnsTestSuite) TestConfiguredColumns() {
+
+	formatter := NewExcelReportFormatter(loggerForTest())
+	formatter.WithColumns(COLUMN_DATE, COLUMN_WEEKDAY, COLUMN_EVENT_PAIRS, COLUMN_BREAKS, COLUMN_TARGET_TIME,
+		COLUMN_DELTA, COLUMN_PROJECT, COLUMN_WORK_LOCATION, COLUMN_ESTIMATED, ExcelColumn("unknown"))
+	report := monthlyReportWithAllTypesForTest()
+	report.Days[0] = Day{
+		Date:         Date{Year: 2022, Month: 1, Day: 3},
+		Type:         WORKDAY,
+		WorkingTime:  7 * time.Hour,
+		BreakTime:    time.Hour,
+		TargetTime:   8 * time.Hour,
+		WorkLocation: HOME,
+		Events: []TimeTrackingRecord{
+			TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-01-03T07:00:00"), Project: "Alpha"},
+			TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-01-03T11:00:00"), Project: "Alpha"},
+			TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-01-03T11:30:00"), Project: "Beta"},
+			TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-01-03T13:00:00")},
+			TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-01-03T13:30:00")},
+			TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-01-03T15:00:00"), Estimated: true},
+		},
+	}
+	report.TargetWorkingTime = 16 * time.Hour
+	xls := suite.generateSheet(formatter, report)
+
+	rows, err := xls.GetRows("2022-01")
+	suite.Nil(err)
+	suite.Equal([]string{"Datum", "Wochentag", "Beginn 1", "Ende 1", "Beginn 2", "Ende 2", "Beginn 3", "Ende 3",
+		"Pausenzeiten", "Sollzeit", "Differenz", "Projekt", "Arbeitsort", "Geschätzt"}, rows[0])
+	suite.Equal([]string{"03.01.2022", "Montag", "08:00", "12:00", "12:30", "14:00", "14:30", "16:00",
+		"12:00-12:30, 14:00-14:30"}, rows[3][0:9])
+	suite.Equal([]string{"Alpha, Beta", "Homeoffice", "Ja"}, rows[3][11:])
+	suite.assertDuration(xls, "J4", 8*time.Hour)
+	suite.assertDuration(xls, "K4", -1*time.Hour)
+	suite.Equal("", rows[4][2])
+	suite.Equal("Summe", rows[32][0])
+	suite.assertFormula(xls, "J33", "SUM(J2:J32)", 8*time.Hour)
+	suite.assertFormula(xls, "K33", "SUM(K2:K32)", 32*time.Hour)
+	suite.Len(rows, 33)
+
+	width, err := xls.GetColWidth("2022-01", "I")
+	suite.Nil(err)
+	suite.Equal(float64(26), width)
+	width, err = xls.GetColWidth("2022-01", "A")
+	suite.Nil(err)
+	suite.Equal(float64(12), width)
+}
+
+func (suite *ExcelColumnsTestSuite) TestFormulas() {
+
+	formatter := NewExcelReportFormatter(loggerForTest())
+	formatter.WithColumns(COLUMN_DATE, COLUMN_WORKING_TIME, COLUMN_TARGET_TIME, COLUMN_DELTA)
+	report := monthlyReportWithAllTypesForTest()
+	report.Days[0].TargetTime = 8 * time.Hour
+	report.Days[1].TargetTime = 8 * time.Hour
+	report.TargetWorkingTime = 16 * time.Hour
+	xls := suite.generateSheet(formatter, report)
+
+	formula, err := xls.GetCellFormula("2022-01", "D2")
+	suite.Nil(err)
+	suite.Equal("B2-C2", formula)
+	suite.assertFormula(xls, "B33", "SUM(B2:B32)", 41*time.Hour)
+	suite.assertFormula(xls, "C33", "SUM(C2:C32)", 16*time.Hour)
+	suite.assertFormula(xls, "D33", "SUM(D2:D32)", 25*time.Hour)
+	overtimeLabel, _ := xls.GetCellValue("2022-01", "A34")
+	suite.Equal("Überstunden", overtimeLabel)
+	suite.assertFormula(xls, "B34", "B33-C33", 25*time.Hour)
+
+	formatter.WithColumns(COLUMN_DATE, COLUMN_WORKING_TIME)
+	xls = suite.generateSheet(formatter, report)
+	suite.assertFormula(xls, "B34", "B33-0.6666666666666666", 25*time.Hour)
+
+	// Updating a single day changes total and overtime
+	formatter.WithColumns(COLUMN_DATE, COLUMN_WORKING_TIME, COLUMN_TARGET_TIME)
+	xls = suite.generateSheet(formatter, report)
+	suite.Nil(xls.SetCellValue("2022-01", "B2", excelDuration(10*time.Hour)))
+	suite.assertFormula(xls, "B34", "B33-C33", 27*time.Hour)
+}
+
+func (suite *ExcelColumnsTestSuite) assertDuration(xls *excelize.File, cell string, expected time.Duration) {
+	value, err := xls.GetCellValue("2022-01", cell, excelize.Options{RawCellValue: true})
+	suite.Nil(err)
+	suite.assertExcelDuration(value, expected)
+}
+
+func (suite *ExcelColumnsTestSuite) assertFormula(xls *excelize.File, cell, expectedFormula string, expected time.Duration) {
+	formula, err := xls.GetCellFormula("2022-01", cell)
+	suite.Nil(err)
+	suite.Equal(expectedFormula, formula)
+	value, err := xls.CalcCellValue("2022-01", cell)
+	suite.Nil(err)
+	suite.assertExcelDuration(value, expected)
+}
+
+func (suite *ExcelColumnsTestSuite) assertExcelDuration(value string, expected time.Duration) {
+	duration, err := strconv.ParseFloat(value, 64)
+	suite.Nil(err)
+	suite.InDelta(expected.Minutes(), duration*24*60, 0.01)
+}
+
+func (suite *ExcelColumnsTestSuite) generateSheet(formatter *ExcelReportFormatter, report *MonthlyReport) *excelize.File {
+	buf, err := formatter.WriteMonthlyReportToBuffer(report)
+	suite.Nil(err)
+	xls, err := excelize.OpenReader(buf)
+	suite.Nil(err)
+	return xls
+}
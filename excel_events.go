@@ -0,0 +1,47 @@
+package timetracker
+
+import (
+	"github.com/xuri/excelize/v2"
+)
+
+// EventsNumberFormat is the Excel number format for timestamps in events sheet.
+const eventsNumberFormat = "yyyy-mm-dd hh:mm:ss"
+
+// WriteEventsSheet adds a hidden sheet with all time tracking records of given reports, which are
+// the raw data of monthly sheets. First column refers to the monthly sheet of a record.
+func (formatter *ExcelReportFormatter) writeEventsSheet(xls *excelize.File, eventsSheetName string, sheetNames []string, reports []*MonthlyReport) error {
+
+	xls.NewSheet(eventsSheetName)
+	headers := []messageKey{msgSheet, msgDate, msgTimestamp, msgType, msgWorkLocation, msgProject, msgEstimated, msgKey, msgDevice}
+	for idx, header := range headers {
+		xls.SetCellValue(eventsSheetName, getCellId(columnNameOf(idx), 1), formatter.message(header))
+	}
+	lastColumn := columnNameOf(len(headers) - 1)
+	if err := xls.SetCellStyle(eventsSheetName, getCellId("A", 1), getCellId(lastColumn, 1), formatter.headlineStyleId); err != nil {
+		return err
+	}
+	timestampStyleId, err := formatter.numberFormatStyle(xls, 0, eventsNumberFormat)
+	if err != nil {
+		return err
+	}
+
+	row := 2
+	for idx, report := range reports {
+		formatter.applyLocale(report)
+		for _, day := range report.Days {
+			for _, event := range day.Events {
+				values := []any{sheetNames[idx], day.Date.String(), formatter.atTimezone(event.Timestamp), string(event.Type),
+					string(event.WorkLocation), event.Project, event.Estimated, event.Key, event.DeviceId}
+				for column, value := range values {
+					xls.SetCellValue(eventsSheetName, getCellId(columnNameOf(column), row), value)
+				}
+				xls.SetCellStyle(eventsSheetName, getCellId("C", row), getCellId("C", row), timestampStyleId)
+				row++
+			}
+		}
+	}
+	xls.SetColWidth(eventsSheetName, "A", "B", 12)
+	xls.SetColWidth(eventsSheetName, "C", "C", 20)
+	xls.SetColWidth(eventsSheetName, "D", lastColumn, 14)
+	return xls.SetSheetVisible(eventsSheetName, false)
+}
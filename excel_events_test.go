@@ -0,0 +1,40 @@
+package timetracker
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/xuri/excelize/v2"
+	"testing"
+)
+
+type ExcelEventsTestSuite struct {
+	suite.Suite
+}
+
+func TestExcelEventsTestSuite(t *testing.T) {
+	suite.Run(t, new(ExcelEventsTestSuite))
+}
+
+func (suite *ExcelEventsTestSuite) TestEventsSheet() {
+
+	formatter := NewExcelReportFormatter(loggerForTest())
+	report := monthlyReportWithAllTypesForTest()
+	report.Days[0].Events[0].Project = "Alpha"
+	report.Days[0].Events[1].Estimated = true
+
+	buf, err := formatter.WriteMonthlyReportToBuffer(report)
+	suite.Nil(err)
+	xls, err := excelize.OpenReader(buf)
+	suite.Nil(err)
+
+	suite.Equal([]string{"2022-01", "Ereignisse"}, xls.GetSheetList())
+	suite.True(xls.GetSheetVisible("2022-01"))
+	suite.False(xls.GetSheetVisible("Ereignisse"))
+
+	rows, err := xls.GetRows("Ereignisse")
+	suite.Nil(err)
+	suite.Len(rows, 5)
+	suite.Equal([]string{"Tabelle", "Datum", "Zeitpunkt", "Typ", "Arbeitsort", "Projekt", "Geschätzt", "Schlüssel", "Gerät"}, rows[0])
+	suite.Equal([]string{"2022-01", "2022-01-01", "2022-01-01 09:00:00", "workday", "", "Alpha", "FALSE"}, rows[1])
+	suite.Equal([]string{"2022-01", "2022-01-01", "2022-01-01 17:30:00", "workday", "", "", "TRUE"}, rows[2])
+	suite.Equal([]string{"2022-01", "2022-01-17", "2022-01-17 08:00:00", "business_trip"}, rows[3][0:4])
+}
@@ -0,0 +1,324 @@
+package timetracker
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/tommzn/go-log"
+	"github.com/xuri/excelize/v2"
+)
+
+// EventsTimestampFormat is the format of timestamps in events sheet, as read from Excel.
+const eventsTimestampFormat = "2006-01-02 15:04:05"
+
+// MonthlySheetName matches names of monthly sheets, e.g. 2022-01.
+var monthlySheetName = regexp.MustCompile(`^(\d{4})-(\d{2})$`)
+
+// NewExcelImporter returns an importer for Excel files generated by ExcelReportFormatter.
+func NewExcelImporter(repository TimeTrackingRecordRepository, logger log.Logger) *ExcelImporter {
+	return &ExcelImporter{
+		recordImport: newRecordImport(repository, logger),
+	}
+}
+
+// ExcelImporter reads time tracking records from monthly sheets of Excel reports and adds them to a repository.
+// Columns are identified by their header in any supported language, so reports in all languages can be imported.
+type ExcelImporter struct {
+
+	// RecordImport adds records to a repository and provides dry-run mode.
+	recordImport
+
+	// Locale defines date format and timezone used in imported Excel files.
+	locale Locale
+}
+
+// ExcelImportColumns contains indexes of all columns used for an import.
+type excelImportColumns struct {
+	date, start, end, comment, estimated int
+	eventPairs                           [][2]int
+}
+
+// WithLocale defines date format and timezone used in Excel files. Timestamps are treated as UTC if there's no timezone.
+func (importer *ExcelImporter) WithLocale(locale Locale) {
+	importer.locale = locale
+}
+
+// ImportFile reads all monthly sheets of given Excel file and adds time tracking records for given device.
+func (importer *ExcelImporter) ImportFile(deviceId, filename string) (*ImportResult, error) {
+	xls, err := excelize.OpenFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer xls.Close()
+	return importer.importRecords(deviceId, xls)
+}
+
+// Import reads all monthly sheets of an Excel file from given reader and adds time tracking records for given device.
+func (importer *ExcelImporter) Import(deviceId string, reader io.Reader) (*ImportResult, error) {
+	xls, err := excelize.OpenReader(reader)
+	if err != nil {
+		return nil, err
+	}
+	defer xls.Close()
+	return importer.importRecords(deviceId, xls)
+}
+
+// ImportRecords reads records from all monthly sheets, named YYYY-MM, and adds them to a repository.
+func (importer *ExcelImporter) importRecords(deviceId string, xls *excelize.File) (*ImportResult, error) {
+
+	sheetNames := []string{}
+	for _, sheetName := range xls.GetSheetList() {
+		if monthlySheetName.MatchString(sheetName) {
+			sheetNames = append(sheetNames, sheetName)
+		}
+	}
+	sort.Strings(sheetNames)
+
+	events, err := importer.eventsOf(xls)
+	if err != nil {
+		return nil, err
+	}
+	records := []TimeTrackingRecord{}
+	latestType := WORKDAY
+	for _, sheetName := range sheetNames {
+		sheetRecords, err := importer.readSheet(xls, sheetName, &latestType, events)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, sheetRecords...)
+	}
+	return importer.addRecords(deviceId, records)
+}
+
+// ReadSheet returns time tracking records of all days in given monthly sheet.
+// Days with a record type in comment column, e.g. vacation, get a record of this type.
+// Passed latest type is the type of last day of previous sheet and will be updated to the type of last day in this sheet.
+// Passed events of a report are used to determine type of each record and to skip estimated records.
+func (importer *ExcelImporter) readSheet(xls *excelize.File, sheetName string, latestType *RecordType, events map[time.Time]excelEvent) ([]TimeTrackingRecord, error) {
+
+	records := []TimeTrackingRecord{}
+	rows, err := xls.GetRows(sheetName)
+	if err != nil || len(rows) == 0 {
+		return records, err
+	}
+	columns, err := excelImportColumnsOf(rows[0])
+	if err != nil {
+		return nil, fmt.Errorf("Invalid sheet %s: %s", sheetName, err)
+	}
+	sheetDate := monthlySheetName.FindStringSubmatch(sheetName)
+	year, _ := strconv.Atoi(sheetDate[1])
+	month, _ := strconv.Atoi(sheetDate[2])
+
+	for _, row := range rows[1:] {
+		date, ok := importer.parseDate(cellValue(row, columns.date))
+		if !ok || date.Year != year || date.Month != month {
+			continue
+		}
+		records = append(records, importer.recordsOfRow(date, row, columns, latestType, events)...)
+	}
+	return records, nil
+}
+
+// RecordsOfRow returns all records for a single day. Type of a record is taken from events sheet of a report. For reports
+// without events sheet, times are imported as WORKDAY records, or with type defined by comment column if it's a working type,
+// e.g. business trip. A single time at a day of illness, vacation, etc. is imported with this type.
+// Days of continuous types, e.g. vacation, have been filled in a report until next day with records. Only first day of
+// such a range gets a record, because following days will be filled again if a report is generated for imported records.
+// Estimated times are skipped, because they'd become captured records and the end of a working day would no longer be estimated.
+func (importer *ExcelImporter) recordsOfRow(date Date, row []string, columns excelImportColumns, latestType *RecordType, events map[time.Time]excelEvent) []TimeTrackingRecord {
+
+	recordType := WORKDAY
+	if key, ok := messageKeyOf(cellValue(row, columns.comment)); ok {
+		if _, isRecordType := recordTypeFormats()[RecordType(key)]; isRecordType {
+			recordType = RecordType(key)
+		}
+	}
+
+	timestamps := []time.Time{}
+	for _, eventPair := range columns.eventPairs {
+		for _, column := range eventPair {
+			if timeOfDay, ok := parseTimeOfDay(cellValue(row, column)); ok {
+				timestamps = append(timestamps, importer.timestampOf(date, timeOfDay))
+			}
+		}
+	}
+	// Without an events sheet, an estimated column marks days with an estimated end of work, which is always the last event.
+	if key, ok := messageKeyOf(cellValue(row, columns.estimated)); ok && key == msgYes && len(timestamps) > 0 {
+		timestamps = timestamps[:len(timestamps)-1]
+	}
+
+	defaultType := WORKDAY
+	if isWorkingType(recordType) || len(timestamps) == 1 {
+		defaultType = recordType
+	}
+	records := []TimeTrackingRecord{}
+	hasRecordType := false
+	for _, timestamp := range timestamps {
+		event, ok := events[timestamp]
+		if !ok {
+			event = excelEvent{recordType: defaultType}
+		}
+		if event.estimated {
+			continue
+		}
+		records = append(records, TimeTrackingRecord{Type: event.recordType, Timestamp: timestamp})
+		hasRecordType = hasRecordType || event.recordType == recordType
+	}
+	if recordType != WORKDAY && recordType != *latestType && !hasRecordType {
+		// Records for whole days are created at noon to keep them at same day in all timezones.
+		records = append(records, TimeTrackingRecord{Type: recordType, Timestamp: importer.timestampOf(date, 12*time.Hour)})
+	}
+	*latestType = recordType
+	return records
+}
+
+// ExcelEvent contains type of a time tracking record listed in events sheet and if it has been estimated.
+type excelEvent struct {
+	recordType RecordType
+	estimated  bool
+}
+
+// EventsOf returns all time tracking records listed in hidden events sheet of a report, with their timestamp in UTC as key.
+func (importer *ExcelImporter) eventsOf(xls *excelize.File) (map[time.Time]excelEvent, error) {
+
+	events := make(map[time.Time]excelEvent)
+	for _, sheetName := range xls.GetSheetList() {
+		if key, ok := messageKeyOf(sheetName); !ok || key != msgEvents {
+			continue
+		}
+		rows, err := xls.GetRows(sheetName)
+		if err != nil || len(rows) == 0 {
+			return events, err
+		}
+		timestampColumn, typeColumn, estimatedColumn := -1, -1, -1
+		for idx, header := range rows[0] {
+			switch key, _ := messageKeyOf(header); key {
+			case msgTimestamp:
+				timestampColumn = idx
+			case msgType:
+				typeColumn = idx
+			case msgEstimated:
+				estimatedColumn = idx
+			}
+		}
+		for _, row := range rows[1:] {
+			timestamp, err := time.ParseInLocation(eventsTimestampFormat, cellValue(row, timestampColumn), locationOf(importer.locale))
+			if err != nil {
+				continue
+			}
+			recordType := RecordType(cellValue(row, typeColumn))
+			if _, ok := recordTypeFormats()[recordType]; !ok && recordType != WORKDAY {
+				continue
+			}
+			events[timestamp.UTC()] = excelEvent{recordType: recordType, estimated: strings.EqualFold(cellValue(row, estimatedColumn), "true")}
+		}
+	}
+	return events, nil
+}
+
+// ParseDate tries to parse given value with date format of current locale and with default date formats.
+func (importer *ExcelImporter) parseDate(value string) (Date, bool) {
+
+	dateFormats := []string{"2006-01-02", "02.01.2006", "01/02/2006"}
+	if importer.locale.DateFormat != nil {
+		dateFormats = append([]string{*importer.locale.DateFormat}, dateFormats...)
+	}
+	for _, dateFormat := range dateFormats {
+		if date, err := time.Parse(dateFormat, strings.TrimSpace(value)); err == nil {
+			return asDate(date), true
+		}
+	}
+	return Date{}, false
+}
+
+// TimestampOf returns point in time, in UTC, for given date and time of day in timezone of current locale.
+func (importer *ExcelImporter) timestampOf(date Date, timeOfDay time.Duration) time.Time {
+	return time.Date(date.Year, time.Month(date.Month), date.Day, 0, 0, 0, 0, locationOf(importer.locale)).Add(timeOfDay).UTC()
+}
+
+// ExcelImportColumnsOf identifies all columns used for an import by their header.
+// Start and end of all event pairs are used, if available, otherwise start and end column.
+func excelImportColumnsOf(headers []string) (excelImportColumns, error) {
+
+	columns := excelImportColumns{date: -1, start: -1, end: -1, comment: -1, estimated: -1, eventPairs: [][2]int{}}
+	eventPairs := make(map[int][2]int)
+	for idx, header := range headers {
+
+		fields := strings.Fields(header)
+		if len(fields) == 2 {
+			if pairIdx, err := strconv.Atoi(fields[1]); err == nil && pairIdx > 0 {
+				key, _ := messageKeyOf(fields[0])
+				eventPair, ok := eventPairs[pairIdx]
+				if !ok {
+					eventPair = [2]int{-1, -1}
+				}
+				switch key {
+				case msgStart:
+					eventPair[0] = idx
+				case msgEnd:
+					eventPair[1] = idx
+				}
+				eventPairs[pairIdx] = eventPair
+				continue
+			}
+		}
+
+		key, _ := messageKeyOf(header)
+		switch key {
+		case msgDate:
+			columns.date = idx
+		case msgStart:
+			columns.start = idx
+		case msgEnd:
+			columns.end = idx
+		case msgComment:
+			columns.comment = idx
+		case msgEstimated:
+			columns.estimated = idx
+		}
+	}
+
+	if columns.date == -1 {
+		return columns, errors.New("Missing date column")
+	}
+	for pairIdx := 1; pairIdx <= len(eventPairs); pairIdx++ {
+		if eventPair, ok := eventPairs[pairIdx]; ok {
+			columns.eventPairs = append(columns.eventPairs, eventPair)
+		}
+	}
+	if len(columns.eventPairs) == 0 {
+		columns.eventPairs = append(columns.eventPairs, [2]int{columns.start, columns.end})
+	}
+	return columns, nil
+}
+
+// ParseTimeOfDay parses a time in format HH:MM or an Excel time value, which is a fraction of a day.
+func parseTimeOfDay(value string) (time.Duration, bool) {
+
+	value = strings.TrimSpace(value)
+	for _, timeFormat := range []string{"15:04", "15:04:05"} {
+		if timestamp, err := time.Parse(timeFormat, value); err == nil {
+			return time.Duration(timestamp.Hour())*time.Hour + time.Duration(timestamp.Minute())*time.Minute, true
+		}
+	}
+	if excelTime, err := strconv.ParseFloat(value, 64); err == nil {
+		_, fraction := math.Modf(excelTime)
+		return time.Duration(math.Round(fraction*24*60)) * time.Minute, true
+	}
+	return 0, false
+}
+
+// CellValue returns value of a cell at given index or an empty string if a row has less columns.
+func cellValue(row []string, idx int) string {
+	if idx < 0 || idx >= len(row) {
+		return ""
+	}
+	return row[idx]
+}
@@ -0,0 +1,211 @@
+package timetracker
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/suite"
+	"github.com/xuri/excelize/v2"
+	"testing"
+	"time"
+)
+
+type ExcelImporterTestSuite struct {
+	suite.Suite
+}
+
+func TestExcelImporterTestSuite(t *testing.T) {
+	suite.Run(t, new(ExcelImporterTestSuite))
+}
+
+func (suite *ExcelImporterTestSuite) TestImportGeneratedReport() {
+
+	records := []TimeTrackingRecord{
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-03-01T07:30:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-03-01T16:00:00")},
+		TimeTrackingRecord{Type: ILLNESS, Timestamp: asTime("2022-03-02T06:00:00")},
+		TimeTrackingRecord{Type: BUSINESS_TRIP, Timestamp: asTime("2022-03-03T06:00:00")},
+		TimeTrackingRecord{Type: BUSINESS_TRIP, Timestamp: asTime("2022-03-03T18:15:00")},
+	}
+	xlsContent := suite.generateReport(records, 2022, 3)
+
+	repository := NewLocaLRepository()
+	importer := NewExcelImporter(repository, loggerForTest())
+	importer.WithLocale(localeForTest())
+	result, err := importer.Import(deviceIdForTest(), bytes.NewReader(xlsContent))
+	suite.Nil(err)
+	suite.False(result.DryRun)
+	suite.Len(result.Records, 5)
+	suite.Len(result.Added, 5)
+	suite.Len(result.Skipped, 0)
+
+	importedRecords, err := repository.ListRecords(deviceIdForTest(), asTime("2022-03-01T00:00:00"), asTime("2022-03-31T23:59:59"))
+	suite.Nil(err)
+	suite.Len(importedRecords, 5)
+	suite.Equal(WORKDAY, importedRecords[0].Type)
+	suite.Equal(asTime("2022-03-01T07:30:00"), importedRecords[0].Timestamp)
+	suite.Equal(asTime("2022-03-01T16:00:00"), importedRecords[1].Timestamp)
+	suite.Equal(ILLNESS, importedRecords[2].Type)
+	suite.Equal(asTime("2022-03-02T06:00:00"), importedRecords[2].Timestamp)
+	suite.Equal(BUSINESS_TRIP, importedRecords[3].Type)
+	suite.Equal(asTime("2022-03-03T06:00:00"), importedRecords[3].Timestamp)
+	suite.Equal(asTime("2022-03-03T18:15:00"), importedRecords[4].Timestamp)
+
+	report1, err := NewReportCalulator(records, localeForTest()).MonthlyReport(2022, 3, WORKDAY)
+	suite.Nil(err)
+	report2, err := NewReportCalulator(importedRecords, localeForTest()).MonthlyReport(2022, 3, WORKDAY)
+	suite.Nil(err)
+	suite.Equal(report1.TotalWorkingTime, report2.TotalWorkingTime)
+
+	result, err = importer.Import(deviceIdForTest(), bytes.NewReader(xlsContent))
+	suite.Nil(err)
+	suite.Len(result.Added, 0)
+	suite.Len(result.Skipped, 5)
+}
+
+func (suite *ExcelImporterTestSuite) TestDryRun() {
+
+	repository := NewLocaLRepository()
+	repository.Add(TimeTrackingRecord{DeviceId: deviceIdForTest(), Type: WORKDAY, Timestamp: asTime("2022-03-01T07:30:00")})
+	records := []TimeTrackingRecord{
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-03-01T07:30:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-03-01T16:00:00")},
+	}
+	xlsContent := suite.generateReport(records, 2022, 3)
+
+	importer := NewExcelImporter(repository, loggerForTest())
+	importer.WithLocale(localeForTest())
+	importer.WithDryRun()
+	result, err := importer.Import(deviceIdForTest(), bytes.NewReader(xlsContent))
+	suite.Nil(err)
+	suite.True(result.DryRun)
+	suite.Len(result.Added, 1)
+	suite.Equal(asTime("2022-03-01T16:00:00"), result.Added[0].Timestamp)
+	suite.Equal("", result.Added[0].Key)
+	suite.Len(result.Skipped, 1)
+
+	existingRecords, err := repository.ListRecords(deviceIdForTest(), asTime("2022-03-01T00:00:00"), asTime("2022-03-31T23:59:59"))
+	suite.Nil(err)
+	suite.Len(existingRecords, 1)
+}
+
+func (suite *ExcelImporterTestSuite) TestImportEventPairs() {
+
+	records := []TimeTrackingRecord{
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-03-01T07:30:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-03-01T11:00:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-03-01T11:45:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-03-01T16:00:00")},
+	}
+	report, err := NewReportCalulator(records, localeForTest()).MonthlyReport(2022, 3, WORKDAY)
+	suite.Nil(err)
+	report.Location.Language = ENGLISH
+	formatter := NewExcelReportFormatter(loggerForTest())
+	formatter.WithColumns(COLUMN_DATE, COLUMN_EVENT_PAIRS, COLUMN_WORKING_TIME)
+	buf, err := formatter.WriteMonthlyReportToBuffer(report)
+	suite.Nil(err)
+
+	importer := NewExcelImporter(NewLocaLRepository(), loggerForTest())
+	importer.WithLocale(localeForTest())
+	result, err := importer.Import(deviceIdForTest(), buf)
+	suite.Nil(err)
+	suite.Len(result.Added, 4)
+	suite.Equal(asTime("2022-03-01T11:45:00"), result.Added[2].Timestamp)
+}
+
+func (suite *ExcelImporterTestSuite) TestSkipEstimatedRecords() {
+
+	records := []TimeTrackingRecord{
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-03-01T07:30:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-03-02T07:30:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-03-02T16:00:00")},
+	}
+	importer := NewExcelImporter(NewLocaLRepository(), loggerForTest())
+	importer.WithLocale(localeForTest())
+	importer.WithDryRun()
+	result, err := importer.Import(deviceIdForTest(), bytes.NewReader(suite.generateReport(records, 2022, 3)))
+	suite.Nil(err)
+	suite.Len(result.Added, 3)
+	for idx, record := range result.Added {
+		suite.Equal(records[idx].Timestamp, record.Timestamp)
+	}
+
+	// Without events sheet, estimated column is used.
+	report, err := NewReportCalulator(records, localeForTest()).MonthlyReport(2022, 3, WORKDAY)
+	suite.Nil(err)
+	report.Location.Language = ENGLISH
+	formatter := NewExcelReportFormatter(loggerForTest())
+	formatter.WithColumns(COLUMN_DATE, COLUMN_START, COLUMN_END, COLUMN_ESTIMATED)
+	buf, err := formatter.WriteMonthlyReportToBuffer(report)
+	suite.Nil(err)
+	xls, err := excelize.OpenReader(buf)
+	suite.Nil(err)
+	xls.DeleteSheet("Events")
+	result, err = importer.importRecords(deviceIdForTest(), xls)
+	suite.Nil(err)
+	suite.Len(result.Added, 3)
+	suite.Equal(asTime("2022-03-01T07:30:00"), result.Added[0].Timestamp)
+}
+
+func (suite *ExcelImporterTestSuite) TestWorkAtDayOfIllness() {
+
+	records := []TimeTrackingRecord{
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-03-01T07:00:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-03-01T09:00:00")},
+		TimeTrackingRecord{Type: ILLNESS, Timestamp: asTime("2022-03-01T11:00:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-03-02T07:30:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-03-02T16:00:00")},
+	}
+	report, err := NewReportCalulator(records, localeForTest()).MonthlyReport(2022, 3, WORKDAY)
+	suite.Nil(err)
+	formatter := NewExcelReportFormatter(loggerForTest())
+	formatter.WithColumns(COLUMN_DATE, COLUMN_EVENT_PAIRS, COLUMN_COMMENT)
+	buf, err := formatter.WriteMonthlyReportToBuffer(report)
+	suite.Nil(err)
+
+	importer := NewExcelImporter(NewLocaLRepository(), loggerForTest())
+	importer.WithLocale(localeForTest())
+	importer.WithDryRun()
+	result, err := importer.Import(deviceIdForTest(), buf)
+	suite.Nil(err)
+	suite.Len(result.Added, 5)
+	sortRecords(result.Added)
+	for idx, record := range result.Added {
+		suite.Equal(records[idx].Type, record.Type)
+		suite.Equal(records[idx].Timestamp, record.Timestamp)
+	}
+}
+
+func (suite *ExcelImporterTestSuite) TestInvalidSheet() {
+
+	formatter := NewExcelReportFormatter(loggerForTest())
+	formatter.WithColumns(COLUMN_WORKING_TIME, COLUMN_COMMENT)
+	buf, err := formatter.WriteMonthlyReportToBuffer(monthlyReportForTest())
+	suite.Nil(err)
+
+	_, err = NewExcelImporter(NewLocaLRepository(), loggerForTest()).Import(deviceIdForTest(), buf)
+	suite.NotNil(err)
+
+	_, err = NewExcelImporter(NewLocaLRepository(), loggerForTest()).ImportFile(deviceIdForTest(), "fixtures/not-existing.xlsx")
+	suite.NotNil(err)
+}
+
+func (suite *ExcelImporterTestSuite) TestParseTimeOfDay() {
+
+	timeOfDay, ok := parseTimeOfDay("08:15")
+	suite.True(ok)
+	suite.Equal(8*time.Hour+15*time.Minute, timeOfDay)
+
+	timeOfDay, ok = parseTimeOfDay("44621.34375")
+	suite.True(ok)
+	suite.Equal(8*time.Hour+15*time.Minute, timeOfDay)
+
+	_, ok = parseTimeOfDay("")
+	suite.False(ok)
+}
+
+func (suite *ExcelImporterTestSuite) generateReport(records []TimeTrackingRecord, year, month int) []byte {
+	report, err := NewReportCalulator(records, localeForTest()).MonthlyReport(year, month, WORKDAY)
+	suite.Nil(err)
+	buf, err := NewExcelReportFormatter(loggerForTest()).WriteMonthlyReportToBuffer(report)
+	suite.Nil(err)
+	return buf.Bytes()
+}
@@ -0,0 +1,125 @@
+package timetracker
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// WriteTeamReportToFile will generate a team report output an writes it to given file.
+func (formatter *ExcelReportFormatter) WriteTeamReportToFile(report *TeamReport, filename string) error {
+
+	xls, err := formatter.generateTeamOutput(report)
+	if err != nil {
+		return err
+	}
+	return xls.SaveAs(filename)
+}
+
+// WriteTeamReportToBuffer returns a buffer for gemerated team report output.
+func (formatter *ExcelReportFormatter) WriteTeamReportToBuffer(report *TeamReport) (*bytes.Buffer, error) {
+	xls, err := formatter.generateTeamOutput(report)
+	if err != nil {
+		return nil, err
+	}
+	return xls.WriteToBuffer()
+}
+
+// GenerateTeamOutput creates an Excel file with an overview sheet and a sheet for each team member.
+// Overview sheet and hidden events sheet use language of first team member.
+func (formatter *ExcelReportFormatter) generateTeamOutput(report *TeamReport) (*excelize.File, error) {
+
+	if len(report.Members) > 0 {
+		formatter.messages = messagesFor(report.Members[0].Member.Locale)
+	}
+	xls := newExcelFile(formatter.message(msgOverview))
+	if err := formatter.createStyles(xls); err != nil {
+		return nil, err
+	}
+	if err := formatter.writeTeamOverview(xls, report); err != nil {
+		return nil, err
+	}
+
+	eventsSheetName := formatter.message(msgEvents)
+	sheetNames := map[string]bool{strings.ToLower(eventsSheetName): true}
+	memberSheetNames := []string{}
+	memberReports := []*MonthlyReport{}
+	for _, memberReport := range report.Members {
+
+		sheetName := formatter.uniqueSheetName(memberReport.Member.Name, sheetNames)
+		xls.NewSheet(sheetName)
+		if err := formatter.writeMonthlySheet(xls, sheetName, memberReport.Report); err != nil {
+			return nil, err
+		}
+		memberSheetNames = append(memberSheetNames, sheetName)
+		memberReports = append(memberReports, memberReport.Report)
+	}
+	if err := formatter.writeEventsSheet(xls, eventsSheetName, memberSheetNames, memberReports); err != nil {
+		return nil, err
+	}
+	return xls, nil
+}
+
+// WriteTeamOverview writes total working time, overtime, absence days and compliance state
+// of each team member to overview sheet.
+func (formatter *ExcelReportFormatter) writeTeamOverview(xls *excelize.File, report *TeamReport) error {
+
+	sheetName := formatter.message(msgOverview)
+	headers := []messageKey{msgName, msgWorkingTime, msgTargetTime, msgOvertime, msgAbsenceDays, msgCompliance}
+	for idx, header := range headers {
+		xls.SetCellValue(sheetName, getCellId(string(rune('A'+idx)), 1), formatter.message(header))
+	}
+	if err := xls.SetCellStyle(sheetName, getCellId("A", 1), getCellId("F", 1), formatter.headlineStyleId); err != nil {
+		return err
+	}
+
+	durationStyleId, err := formatter.numberFormatStyle(xls, 0, durationNumberFormat)
+	if err != nil {
+		return err
+	}
+
+	row := 2
+	for _, memberReport := range report.Members {
+		summary := memberReport.Summary
+		xls.SetCellValue(sheetName, getCellId("A", row), memberReport.Member.Name)
+		xls.SetCellValue(sheetName, getCellId("B", row), excelDuration(summary.TotalWorkingTime))
+		xls.SetCellValue(sheetName, getCellId("C", row), excelDuration(summary.TargetWorkingTime))
+		xls.SetCellValue(sheetName, getCellId("D", row), excelDuration(summary.Overtime))
+		xls.SetCellStyle(sheetName, getCellId("B", row), getCellId("D", row), durationStyleId)
+		xls.SetCellValue(sheetName, getCellId("E", row), summary.TotalAbsenceDays())
+		xls.SetCellValue(sheetName, getCellId("F", row), formatter.complianceComment(summary))
+		row++
+	}
+	xls.SetColWidth(sheetName, "A", "A", 30)
+	xls.SetColWidth(sheetName, "B", "E", 12)
+	xls.SetColWidth(sheetName, "F", "F", 50)
+	return nil
+}
+
+// UniqueSheetName returns a valid sheet name, max 31 characters without special characters, for given name.
+// A counter will be appended if a sheet name already exists. Excel doesn't distinguish sheet names by case,
+// so names are compared case-insensitive and existing sheet names are expected in lower case.
+func (formatter *ExcelReportFormatter) uniqueSheetName(name string, existingSheetNames map[string]bool) string {
+
+	sheetName := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	if sheetName == "" || strings.ToLower(sheetName) == strings.ToLower(formatter.message(msgOverview)) {
+		sheetName = formatter.message(msgMember)
+	}
+	if len([]rune(sheetName)) > 28 {
+		sheetName = string([]rune(sheetName)[:28])
+	}
+
+	uniqueName := sheetName
+	for counter := 2; existingSheetNames[strings.ToLower(uniqueName)]; counter++ {
+		uniqueName = fmt.Sprintf("%s %d", sheetName, counter)
+	}
+	existingSheetNames[strings.ToLower(uniqueName)] = true
+	return uniqueName
+}
@@ -2,7 +2,9 @@ package timetracker
 
 import (
 	"github.com/stretchr/testify/suite"
+	"github.com/xuri/excelize/v2"
 	"os"
+	"strings"
 	"testing"
 	"time"
 )
@@ -22,12 +24,100 @@ func (suite *ExcelReportFormatterTestSuite) TestGenerateReport() {
 
 	suite.withHolidays(formatter, report.Year, report.Month)
 
-	suite.Nil(formatter.WriteMonthlyReportToFile(report, "report.xlsx"))
+	suite.Nil(formatter.WriteMonthlyReportToFile(report, suite.T().TempDir()+"/report.xlsx"))
 	buf, err := formatter.WriteMonthlyReportToBuffer(report)
 	suite.Nil(err)
 	suite.True(len(buf.Bytes()) > 0)
 }
 
+func (suite *ExcelReportFormatterTestSuite) TestGenerateTeamReport() {
+
+	formatter := NewExcelReportFormatter(loggerForTest())
+	report := &TeamReport{
+		Year:  2022,
+		Month: 1,
+		Members: []TeamMemberReport{
+			TeamMemberReport{Member: TeamMember{Id: "Device01", Name: "Jane Doe"}, Report: monthlyReportForTest()},
+			TeamMemberReport{Member: TeamMember{Id: "Device02", Name: "Jane Doe"}, Report: monthlyReportForTest()},
+		},
+	}
+	for idx, memberReport := range report.Members {
+		report.Members[idx].Summary = SummarizeMonthlyReport(memberReport.Report)
+	}
+
+	buf, err := formatter.WriteTeamReportToBuffer(report)
+	suite.Nil(err)
+	xls, err := excelize.OpenReader(buf)
+	suite.Nil(err)
+	suite.Equal([]string{"Overview", "Jane Doe", "Jane Doe 2", "Events"}, xls.GetSheetList())
+	suite.False(xls.GetSheetVisible("Events"))
+	name, _ := xls.GetCellValue("Overview", "A2")
+	suite.Equal("Jane Doe", name)
+	compliance, _ := xls.GetCellValue("Overview", "F2")
+	suite.Equal("OK", compliance)
+}
+
+func (suite *ExcelReportFormatterTestSuite) TestRecordTypeLabelsAndColors() {
+
+	report := monthlyReportWithAllTypesForTest()
+	buf, err := NewExcelReportFormatter(loggerForTest()).WriteMonthlyReportToBuffer(report)
+	suite.Nil(err)
+	xls, err := excelize.OpenReader(buf)
+	suite.Nil(err)
+
+	expectations := []struct {
+		day   int
+		label string
+		color string
+	}{
+		{17, "Dienstreise", "6699CC"},
+		{18, "Schulung", "9999FF"},
+		{19, "Freizeitausgleich", "FFCC00"},
+		{20, "Sonderurlaub", "66CCCC"},
+		{21, "Elternzeit", "CC99CC"},
+	}
+	for _, expectation := range expectations {
+		comment, err := xls.GetCellValue("2022-01", getCellId("F", expectation.day+1))
+		suite.Nil(err)
+		suite.Equal(expectation.label, comment)
+		suite.Equal(expectation.color, suite.fillColorOf(xls, "2022-01", getCellId("A", expectation.day+1)))
+	}
+
+	report.Location.Language = ENGLISH
+	buf, err = NewExcelReportFormatter(loggerForTest()).WriteMonthlyReportToBuffer(report)
+	suite.Nil(err)
+	xls, err = excelize.OpenReader(buf)
+	suite.Nil(err)
+	for day, label := range map[int]string{17: "Business Trip", 18: "Training", 19: "Comp Time Off", 20: "Special Leave", 21: "Parental Leave"} {
+		comment, err := xls.GetCellValue("2022-01", getCellId("F", day+1))
+		suite.Nil(err)
+		suite.Equal(label, comment)
+	}
+}
+
+func (suite *ExcelReportFormatterTestSuite) fillColorOf(xls *excelize.File, sheetName, cell string) string {
+	styleId, err := xls.GetCellStyle(sheetName, cell)
+	suite.Nil(err)
+	fill := xls.Styles.Fills.Fill[*xls.Styles.CellXfs.Xf[styleId].FillID]
+	suite.NotNil(fill.PatternFill)
+	suite.NotNil(fill.PatternFill.FgColor)
+	rgb := strings.ToUpper(fill.PatternFill.FgColor.RGB)
+	return rgb[len(rgb)-6:]
+}
+
+func (suite *ExcelReportFormatterTestSuite) TestUniqueSheetName() {
+
+	formatter := NewExcelReportFormatter(loggerForTest())
+	sheetNames := make(map[string]bool)
+	suite.Equal("Jane_Doe", formatter.uniqueSheetName("Jane/Doe", sheetNames))
+	suite.Equal("Jane_Doe 2", formatter.uniqueSheetName("Jane:Doe", sheetNames))
+	suite.Equal("Member", formatter.uniqueSheetName("Overview", sheetNames))
+	suite.Len([]rune(formatter.uniqueSheetName("A very long name of a team member", sheetNames)), 28)
+	suite.Equal("Member 2", formatter.uniqueSheetName("overview", sheetNames))
+	suite.Equal("Alice", formatter.uniqueSheetName("Alice", sheetNames))
+	suite.Equal("alice 2", formatter.uniqueSheetName("alice", sheetNames))
+}
+
 func (suite *ExcelReportFormatterTestSuite) withHolidays(formatter ReportFormatter, year, month int) {
 	if _, isSet := os.LookupEnv("CI"); !isSet {
 		api, ok := holidayApiForTest()
@@ -79,3 +169,50 @@ func monthlyReportForTest() *MonthlyReport {
 		TotalWorkingTime: 8 * time.Hour,
 	}
 }
+
+// MonthlyReportWithAllTypesForTest returns a report with days of all record types, e.g. business trips and parental leave.
+func monthlyReportWithAllTypesForTest() *MonthlyReport {
+	report := monthlyReportForTest()
+	report.Days = append(report.Days,
+		Day{
+			Date:        Date{Year: 2022, Month: 1, Day: 17},
+			Type:        BUSINESS_TRIP,
+			WorkingTime: 9 * time.Hour,
+			BreakTime:   45 * time.Minute,
+			Events: []TimeTrackingRecord{
+				TimeTrackingRecord{Type: BUSINESS_TRIP, Timestamp: asTime("2022-01-17T07:00:00")},
+				TimeTrackingRecord{Type: BUSINESS_TRIP, Timestamp: asTime("2022-01-17T16:45:00")},
+			},
+		},
+		Day{
+			Date:        Date{Year: 2022, Month: 1, Day: 18},
+			Type:        TRAINING,
+			WorkingTime: 0,
+			BreakTime:   0,
+			Events:      []TimeTrackingRecord{},
+		},
+		Day{
+			Date:        Date{Year: 2022, Month: 1, Day: 19},
+			Type:        COMP_TIME_OFF,
+			WorkingTime: 0,
+			BreakTime:   0,
+			Events:      []TimeTrackingRecord{},
+		},
+		Day{
+			Date:        Date{Year: 2022, Month: 1, Day: 20},
+			Type:        SPECIAL_LEAVE,
+			WorkingTime: 0,
+			BreakTime:   0,
+			Events:      []TimeTrackingRecord{},
+		},
+		Day{
+			Date:        Date{Year: 2022, Month: 1, Day: 21},
+			Type:        PARENTAL_LEAVE,
+			WorkingTime: 0,
+			BreakTime:   0,
+			Events:      []TimeTrackingRecord{},
+		},
+	)
+	report.TotalWorkingTime = 17 * time.Hour
+	return report
+}
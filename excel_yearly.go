@@ -0,0 +1,107 @@
+package timetracker
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// WriteYearlyReportToFile will generate a yearly report output an writes it to given file.
+func (formatter *ExcelReportFormatter) WriteYearlyReportToFile(report *YearlyReport, filename string) error {
+
+	xls, err := formatter.generateYearlyOutput(report)
+	if err != nil {
+		return err
+	}
+	return xls.SaveAs(filename)
+}
+
+// WriteYearlyReportToBuffer returns a buffer for gemerated yearly report output.
+func (formatter *ExcelReportFormatter) WriteYearlyReportToBuffer(report *YearlyReport) (*bytes.Buffer, error) {
+	xls, err := formatter.generateYearlyOutput(report)
+	if err != nil {
+		return nil, err
+	}
+	return xls.WriteToBuffer()
+}
+
+// GenerateYearlyOutput creates an Excel file with an overview sheet for given year and a sheet for each month.
+// Monthly sheets are named YYYY-MM, so a yearly report can be imported by ExcelImporter.
+func (formatter *ExcelReportFormatter) generateYearlyOutput(report *YearlyReport) (*excelize.File, error) {
+
+	formatter.messages = messagesFor(report.Location)
+	xls := newExcelFile(formatter.message(msgOverview))
+	if err := formatter.createStyles(xls); err != nil {
+		return nil, err
+	}
+	if err := formatter.writeYearlyOverview(xls, report); err != nil {
+		return nil, err
+	}
+
+	sheetNames := []string{}
+	monthlyReports := []*MonthlyReport{}
+	for idx := range report.Months {
+		monthlyReport := &report.Months[idx]
+		sheetName := fmt.Sprintf("%04d-%02d", monthlyReport.Year, monthlyReport.Month)
+		xls.NewSheet(sheetName)
+		if err := formatter.writeMonthlySheet(xls, sheetName, monthlyReport); err != nil {
+			return nil, err
+		}
+		sheetNames = append(sheetNames, sheetName)
+		monthlyReports = append(monthlyReports, monthlyReport)
+	}
+	if err := formatter.writeEventsSheet(xls, formatter.message(msgEvents), sheetNames, monthlyReports); err != nil {
+		return nil, err
+	}
+	return xls, nil
+}
+
+// WriteYearlyOverview writes working time, target working time, overtime and days per work location
+// of each month to overview sheet, followed by totals of the year.
+func (formatter *ExcelReportFormatter) writeYearlyOverview(xls *excelize.File, report *YearlyReport) error {
+
+	sheetName := formatter.message(msgOverview)
+	locations := formatter.workLocationDaysOf(report.WorkLocationDays)
+	headers := []string{formatter.message(msgMonth), formatter.message(msgWorkingTime), formatter.message(msgTargetTime), formatter.message(msgOvertime)}
+	for _, location := range locations {
+		headers = append(headers, location.label)
+	}
+	lastColumn := columnNameOf(len(headers) - 1)
+	for idx, header := range headers {
+		xls.SetCellValue(sheetName, getCellId(columnNameOf(idx), 1), header)
+	}
+	if err := xls.SetCellStyle(sheetName, getCellId("A", 1), getCellId(lastColumn, 1), formatter.headlineStyleId); err != nil {
+		return err
+	}
+
+	durationStyleId, err := formatter.numberFormatStyle(xls, 0, durationNumberFormat)
+	if err != nil {
+		return err
+	}
+	writeRow := func(row int, label string, workingTime, targetWorkingTime, overtime time.Duration, workLocationDays map[WorkLocation]int) {
+		xls.SetCellValue(sheetName, getCellId("A", row), label)
+		xls.SetCellValue(sheetName, getCellId("B", row), excelDuration(workingTime))
+		xls.SetCellValue(sheetName, getCellId("C", row), excelDuration(targetWorkingTime))
+		xls.SetCellValue(sheetName, getCellId("D", row), excelDuration(overtime))
+		xls.SetCellStyle(sheetName, getCellId("B", row), getCellId("D", row), durationStyleId)
+		for idx, location := range locations {
+			xls.SetCellValue(sheetName, getCellId(columnNameOf(idx+4), row), workLocationDays[location.workLocation])
+		}
+	}
+
+	row := 2
+	for _, monthlyReport := range report.Months {
+		writeRow(row, formatter.messages.month(time.Month(monthlyReport.Month)), monthlyReport.TotalWorkingTime,
+			monthlyReport.TargetWorkingTime, monthlyReport.Overtime, monthlyReport.WorkLocationDays)
+		row++
+	}
+	writeRow(row, formatter.message(msgTotal), report.TotalWorkingTime, report.TargetWorkingTime, report.Overtime, report.WorkLocationDays)
+	if err := xls.SetCellStyle(sheetName, getCellId("A", row), getCellId("A", row), formatter.headlineStyleId); err != nil {
+		return err
+	}
+	xls.SetColWidth(sheetName, "A", "A", 20)
+	xls.SetColWidth(sheetName, "B", lastColumn, 16)
+	return nil
+}
@@ -0,0 +1,64 @@
+package timetracker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xuri/excelize/v2"
+)
+
+type ExcelYearlyReportTestSuite struct {
+	suite.Suite
+}
+
+func TestExcelYearlyReportTestSuite(t *testing.T) {
+	suite.Run(t, new(ExcelYearlyReportTestSuite))
+}
+
+func (suite *ExcelYearlyReportTestSuite) TestYearlyReport() {
+
+	records := []TimeTrackingRecord{
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-01-03T07:00:00"), WorkLocation: HOME},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-01-03T15:00:00"), WorkLocation: HOME},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-01T07:00:00"), WorkLocation: OFFICE},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-01T15:00:00"), WorkLocation: OFFICE},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-02T07:00:00"), WorkLocation: HOME},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-02T15:00:00"), WorkLocation: HOME},
+	}
+	report, err := NewReportCalulator(records, localeForTest()).YearlyReport(2022, WORKDAY)
+	suite.Nil(err)
+
+	formatter := NewExcelReportFormatter(loggerForTest())
+	buf, err := formatter.WriteYearlyReportToBuffer(report)
+	suite.Nil(err)
+	xls, err := excelize.OpenReader(buf)
+	suite.Nil(err)
+
+	sheetNames := xls.GetSheetList()
+	suite.Len(sheetNames, 14)
+	suite.Equal("2022-01", sheetNames[1])
+	suite.Equal("2022-12", sheetNames[12])
+
+	rows, err := xls.GetRows(sheetNames[0])
+	suite.Nil(err)
+	suite.Len(rows, 14)
+	suite.Equal([]string{"Monat", "Arbeitszeit", "Sollzeit", "Überstunden", "Homeoffice (Tage)", "Büro (Tage)"}, rows[0])
+	suite.Equal([]string{"Januar", "1", "0"}, []string{rows[1][0], rows[1][4], rows[1][5]})
+	suite.Equal([]string{"Februar", "1", "1"}, []string{rows[2][0], rows[2][4], rows[2][5]})
+	suite.Equal([]string{"Summe", "2", "1"}, []string{rows[13][0], rows[13][4], rows[13][5]})
+
+	monthlyRows, err := xls.GetRows("2022-02")
+	suite.Nil(err)
+	suite.Equal([]string{"Homeoffice (Tage)", "1"}, monthlyRows[len(monthlyRows)-2])
+	suite.Equal([]string{"Büro (Tage)", "1"}, monthlyRows[len(monthlyRows)-1][0:2])
+
+	importer := NewExcelImporter(NewLocaLRepository(), loggerForTest())
+	importer.WithLocale(localeForTest())
+	importer.WithDryRun()
+	result, err := importer.importRecords(deviceIdForTest(), xls)
+	suite.Nil(err)
+	suite.Len(result.Added, 6)
+
+	filename := suite.T().TempDir() + "/report" + formatter.FileExtension()
+	suite.Nil(formatter.WriteYearlyReportToFile(report, filename))
+}
@@ -0,0 +1,224 @@
+package timetracker
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/tommzn/go-log"
+)
+
+// NewFanOutPublisher returns a publisher which sends reports to all added targets concurrently.
+func NewFanOutPublisher(logger log.Logger) *FanOutPublisher {
+	return &FanOutPublisher{
+		targets: []publishTarget{},
+		logger:  logger,
+	}
+}
+
+// FanOutPublisher sends a report to several publishers, e.g. S3, email and webhook, concurrently.
+// Each target is retried with its own retry policy and a failing target doesn't block the others,
+// except targets which depend on it, e.g. an email with a link to a report uploaded to S3.
+type FanOutPublisher struct {
+
+	// Targets a report is sent to.
+	targets []publishTarget
+
+	logger log.Logger
+}
+
+// PublishTarget is a publisher with a name, used in results, a retry policy and names of targets
+// which have to succeed before a report is sent to this target.
+type publishTarget struct {
+	name        string
+	publisher   ReportPublisher
+	retryPolicy RetryPolicy
+	dependsOn   []string
+}
+
+// PublishResult is the outcome of sending a report to a single target.
+type PublishResult struct {
+
+	// Target is the name of a publisher.
+	Target string
+
+	// Attempts is the number of calls to a publisher.
+	Attempts int
+
+	// Duration is the time spent for all attempts.
+	Duration time.Duration
+
+	// Err is the error of the last attempt, nil if a report has been sent successfully.
+	Err error
+}
+
+// PublishReport contains results of all targets, in order they've been added.
+type PublishReport struct {
+	Results []PublishResult
+}
+
+// Succeeded returns names of all targets a report has been sent to.
+func (report PublishReport) Succeeded() []string {
+	targets := []string{}
+	for _, result := range report.Results {
+		if result.Err == nil {
+			targets = append(targets, result.Target)
+		}
+	}
+	return targets
+}
+
+// Failed returns names of all targets which failed.
+func (report PublishReport) Failed() []string {
+	targets := []string{}
+	for _, result := range report.Results {
+		if result.Err != nil {
+			targets = append(targets, result.Target)
+		}
+	}
+	return targets
+}
+
+// Err returns a PublishError with all failed targets, or nil if a report has been sent to all targets.
+func (report PublishReport) Err() error {
+	if len(report.Failed()) == 0 {
+		return nil
+	}
+	return &PublishError{Report: report}
+}
+
+// PublishError is returned if a report couldn't be sent to at least one target.
+type PublishError struct {
+	Report PublishReport
+}
+
+// Error returns errors of all failed targets.
+func (err *PublishError) Error() string {
+	messages := []string{}
+	for _, result := range err.Report.Results {
+		if result.Err != nil {
+			messages = append(messages, fmt.Sprintf("%s: %s", result.Target, result.Err))
+		}
+	}
+	return fmt.Sprintf("Unable to publish report to %d of %d targets: %s", len(messages), len(err.Report.Results), strings.Join(messages, "; "))
+}
+
+// WithTarget adds a publisher with given name. Failed attempts are retried with given policy,
+// use NoRetries for publishers which retry requests themselves.
+func (publisher *FanOutPublisher) WithTarget(name string, target ReportPublisher, retryPolicy RetryPolicy) {
+	publisher.WithTargetAfter(name, target, retryPolicy)
+}
+
+// WithTargetAfter adds a publisher which is called after all given targets have succeeded. If one of them fails,
+// a report is not sent to this publisher. Use it for publishers which link to a report published by another target,
+// e.g. an email publisher with a link to a report uploaded by S3Publisher. Targets it depends on have to be added before.
+// Target names have to be unique, a report is not sent to a target with the name of a previously added target.
+func (publisher *FanOutPublisher) WithTargetAfter(name string, target ReportPublisher, retryPolicy RetryPolicy, dependsOn ...string) {
+	publisher.targets = append(publisher.targets, publishTarget{name: name, publisher: target, retryPolicy: retryPolicy, dependsOn: dependsOn})
+}
+
+// WithMonthlyReport passes given report to all targets which create a summary of it, e.g. eMail or chat publisher.
+// Targets keep this report for all following sends, so they must not be shared by concurrent publishes of different
+// reports. Use PublishMonthlyReport to pass a report with each publish instead.
+func (publisher *FanOutPublisher) WithMonthlyReport(report *MonthlyReport) {
+	for _, target := range publisher.targets {
+		if summaryPublisher, ok := target.publisher.(ReportSummaryPublisher); ok {
+			summaryPublisher.WithMonthlyReport(report)
+		}
+	}
+}
+
+// Send publishes given report to all targets and returns a PublishError if at least one target failed.
+func (publisher *FanOutPublisher) Send(content []byte, fileName string) error {
+	return publisher.Publish(content, fileName).Err()
+}
+
+// SendMonthlyReport publishes given report together with the monthly report it belongs to and returns
+// a PublishError if at least one target failed.
+func (publisher *FanOutPublisher) SendMonthlyReport(content []byte, fileName string, report *MonthlyReport) error {
+	return publisher.PublishMonthlyReport(content, fileName, report).Err()
+}
+
+// Publish sends given report to all targets concurrently and waits until all of them have been completed.
+// Targets with dependencies wait until all targets they depend on have been completed.
+// Targets with a duplicate name fail without sending a report.
+func (publisher *FanOutPublisher) Publish(content []byte, fileName string) PublishReport {
+	return publisher.PublishMonthlyReport(content, fileName, nil)
+}
+
+// PublishMonthlyReport sends given report to all targets, like Publish, and passes the monthly report it belongs to
+// with each send to targets which create a summary of it. Nothing is stored in targets, so they can be shared by
+// concurrent publishes of different reports. Without a monthly report, targets use reports assigned by WithMonthlyReport.
+func (publisher *FanOutPublisher) PublishMonthlyReport(content []byte, fileName string, monthlyReport *MonthlyReport) PublishReport {
+
+	results := make([]PublishResult, len(publisher.targets))
+	done := make([]chan struct{}, len(publisher.targets))
+	for idx := range publisher.targets {
+		done[idx] = make(chan struct{})
+	}
+	indexes := make(map[string]int)
+	wg := sync.WaitGroup{}
+	for idx, target := range publisher.targets {
+		if _, ok := indexes[target.name]; ok {
+			results[idx] = PublishResult{Target: target.name, Err: fmt.Errorf("Duplicate target name: %s", target.name)}
+			close(done[idx])
+			continue
+		}
+		dependencies := []int{}
+		for _, dependency := range target.dependsOn {
+			dependencyIdx, ok := indexes[dependency]
+			if !ok {
+				results[idx] = PublishResult{Target: target.name, Err: fmt.Errorf("Missing target %s, it has to be added before %s", dependency, target.name)}
+				break
+			}
+			dependencies = append(dependencies, dependencyIdx)
+		}
+		indexes[target.name] = idx
+		if results[idx].Err != nil {
+			close(done[idx])
+			continue
+		}
+
+		wg.Add(1)
+		go func(idx int, target publishTarget, dependencies []int) {
+			defer wg.Done()
+			defer close(done[idx])
+			for _, dependencyIdx := range dependencies {
+				dependency := publisher.targets[dependencyIdx].name
+				<-done[dependencyIdx]
+				if results[dependencyIdx].Err != nil {
+					results[idx] = PublishResult{Target: target.name, Err: fmt.Errorf("Skipped, because %s failed", dependency)}
+					return
+				}
+			}
+			results[idx] = publisher.publishTo(target, content, fileName, monthlyReport)
+		}(idx, target, dependencies)
+	}
+	wg.Wait()
+
+	report := PublishReport{Results: results}
+	if failed := report.Failed(); len(failed) > 0 {
+		publisher.logger.Error("Unable to publish report to: ", strings.Join(failed, ", "))
+	}
+	publisher.logger.Debug("Report published to: ", strings.Join(report.Succeeded(), ", "))
+	return report
+}
+
+// PublishTo sends a report to a single target, retried with its policy. A monthly report is passed
+// to targets which create a summary of it, if it's not nil.
+func (publisher *FanOutPublisher) publishTo(target publishTarget, content []byte, fileName string, monthlyReport *MonthlyReport) PublishResult {
+
+	result := PublishResult{Target: target.name}
+	start := time.Now()
+	summaryPublisher, isSummaryPublisher := target.publisher.(ReportSummaryPublisher)
+	result.Err = target.retryPolicy.do(func() error {
+		result.Attempts++
+		if monthlyReport != nil && isSummaryPublisher {
+			return summaryPublisher.SendMonthlyReport(content, fileName, monthlyReport)
+		}
+		return target.publisher.Send(content, fileName)
+	})
+	result.Duration = time.Since(start)
+	return result
+}
@@ -0,0 +1,203 @@
+package timetracker
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FanOutPublisherTestSuite struct {
+	suite.Suite
+}
+
+func TestFanOutPublisherTestSuite(t *testing.T) {
+	suite.Run(t, new(FanOutPublisherTestSuite))
+}
+
+func (suite *FanOutPublisherTestSuite) TestPublish() {
+
+	archive := &publisherForTest{}
+	email := &publisherForTest{failures: 1}
+	webhook := &publisherForTest{failures: 5}
+	retryPolicy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+
+	publisher := NewFanOutPublisher(loggerForTest())
+	publisher.WithTarget("s3", archive, NoRetries())
+	publisher.WithTarget("email", email, retryPolicy)
+	publisher.WithTarget("webhook", webhook, retryPolicy)
+
+	report := publisher.Publish([]byte("report"), "report_202201.xlsx")
+	suite.Len(report.Results, 3)
+	suite.Equal([]string{"s3", "email"}, report.Succeeded())
+	suite.Equal([]string{"webhook"}, report.Failed())
+	suite.Equal(1, report.Results[0].Attempts)
+	suite.Equal(2, report.Results[1].Attempts)
+	suite.Equal(3, report.Results[2].Attempts)
+	suite.Equal("report_202201.xlsx", archive.fileName)
+	suite.Equal("report", string(archive.content))
+
+	err := report.Err()
+	suite.NotNil(err)
+	var publishErr *PublishError
+	suite.True(errors.As(err, &publishErr))
+	suite.Equal("Unable to publish report to 1 of 3 targets: webhook: Failed", err.Error())
+}
+
+func (suite *FanOutPublisherTestSuite) TestSend() {
+
+	publisher := NewFanOutPublisher(loggerForTest())
+	suite.Nil(publisher.Send([]byte("report"), "report_202201.xlsx"))
+
+	publisher.WithTarget("s3", &publisherForTest{}, NoRetries())
+	publisher.WithTarget("file", &publisherForTest{}, NoRetries())
+	suite.Nil(publisher.Send([]byte("report"), "report_202201.xlsx"))
+
+	publisher.WithTarget("email", &publisherForTest{failures: 1}, NoRetries())
+	suite.NotNil(publisher.Send([]byte("report"), "report_202201.xlsx"))
+}
+
+func (suite *FanOutPublisherTestSuite) TestConcurrentTargets() {
+
+	slow := &publisherForTest{delay: 100 * time.Millisecond}
+	fast := &publisherForTest{}
+
+	publisher := NewFanOutPublisher(loggerForTest())
+	publisher.WithTarget("slow", slow, NoRetries())
+	publisher.WithTarget("fast", fast, NoRetries())
+	report := publisher.Publish([]byte("report"), "report_202201.xlsx")
+
+	suite.Nil(report.Err())
+	suite.True(fast.sentAt.Before(slow.sentAt))
+	suite.True(report.Results[0].Duration >= 100*time.Millisecond)
+}
+
+func (suite *FanOutPublisherTestSuite) TestDependentTargets() {
+
+	archive := &publisherForTest{delay: 50 * time.Millisecond}
+	email := &publisherForTest{}
+	chat := &publisherForTest{}
+
+	publisher := NewFanOutPublisher(loggerForTest())
+	publisher.WithTarget("s3", archive, NoRetries())
+	publisher.WithTargetAfter("email", email, NoRetries(), "s3")
+	publisher.WithTargetAfter("chat", chat, NoRetries(), "email", "s3")
+	report := publisher.Publish([]byte("report"), "report_202201.xlsx")
+	suite.Nil(report.Err())
+	suite.True(archive.sentAt.Before(email.sentAt))
+	suite.True(email.sentAt.Before(chat.sentAt))
+
+	email = &publisherForTest{}
+	publisher = NewFanOutPublisher(loggerForTest())
+	publisher.WithTarget("s3", &publisherForTest{failures: 1}, NoRetries())
+	publisher.WithTargetAfter("email", email, NoRetries(), "s3")
+	report = publisher.Publish([]byte("report"), "report_202201.xlsx")
+	suite.Equal([]string{"s3", "email"}, report.Failed())
+	suite.Equal(0, report.Results[1].Attempts)
+	suite.Equal(int32(0), email.calls)
+	suite.Equal("Unable to publish report to 2 of 2 targets: s3: Failed; email: Skipped, because s3 failed", report.Err().Error())
+
+	publisher = NewFanOutPublisher(loggerForTest())
+	publisher.WithTargetAfter("email", email, NoRetries(), "s3")
+	publisher.WithTarget("s3", &publisherForTest{}, NoRetries())
+	report = publisher.Publish([]byte("report"), "report_202201.xlsx")
+	suite.Equal([]string{"email"}, report.Failed())
+	suite.Equal(int32(0), email.calls)
+}
+
+func (suite *FanOutPublisherTestSuite) TestDuplicateTargetNames() {
+
+	first := &publisherForTest{}
+	second := &publisherForTest{}
+	dependent := &publisherForTest{}
+
+	publisher := NewFanOutPublisher(loggerForTest())
+	publisher.WithTarget("a", first, NoRetries())
+	publisher.WithTarget("a", second, NoRetries())
+	publisher.WithTargetAfter("b", dependent, NoRetries(), "a")
+	report := publisher.Publish([]byte("report"), "report_202201.xlsx")
+
+	suite.Equal([]string{"a", "b"}, report.Succeeded())
+	suite.Equal([]string{"a"}, report.Failed())
+	suite.Equal("Duplicate target name: a", report.Results[1].Err.Error())
+	suite.Equal(int32(1), first.calls)
+	suite.Equal(int32(0), second.calls)
+	suite.Equal(int32(1), dependent.calls)
+}
+
+func (suite *FanOutPublisherTestSuite) TestForwardMonthlyReport() {
+
+	chat := NewChatPublisher(CHAT_SLACK, "http://localhost", loggerForTest())
+	publisher := NewFanOutPublisher(loggerForTest())
+	publisher.WithTarget("file", &publisherForTest{}, NoRetries())
+	publisher.WithTarget("chat", chat, NoRetries())
+
+	report := monthlyReportForTest()
+	publisher.WithMonthlyReport(report)
+	suite.Equal(report, chat.report)
+}
+
+func (suite *FanOutPublisherTestSuite) TestPublishMonthlyReportConcurrently() {
+
+	titles := []string{}
+	lock := sync.Mutex{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		body, _ := io.ReadAll(r.Body)
+		suite.Nil(json.Unmarshal(body, &payload))
+		lock.Lock()
+		defer lock.Unlock()
+		titles = append(titles, payload["text"].(string))
+	}))
+	defer server.Close()
+
+	publisher := NewFanOutPublisher(loggerForTest())
+	publisher.WithTarget("chat", NewChatPublisher(CHAT_SLACK, server.URL, loggerForTest()), NoRetries())
+
+	january := monthlyReportForTest()
+	february := monthlyReportForTest()
+	february.Month = 2
+	february.Location.Language = ENGLISH
+	wg := sync.WaitGroup{}
+	for idx := 0; idx < 10; idx++ {
+		wg.Add(1)
+		go func(report *MonthlyReport) {
+			defer wg.Done()
+			suite.Nil(publisher.PublishMonthlyReport([]byte("report"), "report.xlsx", report).Err())
+		}([]*MonthlyReport{january, february}[idx%2])
+	}
+	wg.Wait()
+
+	sort.Strings(titles)
+	suite.Len(titles, 10)
+	suite.Equal("Arbeitszeitnachweis 2022-01", titles[0])
+	suite.Equal("Arbeitszeitnachweis 2022-01", titles[4])
+	suite.Equal("Time Tracking Report 2022-02", titles[5])
+	suite.Equal("Time Tracking Report 2022-02", titles[9])
+}
+
+type publisherForTest struct {
+	failures int32
+	calls    int32
+	delay    time.Duration
+	content  []byte
+	fileName string
+	sentAt   time.Time
+}
+
+func (publisher *publisherForTest) Send(content []byte, fileName string) error {
+	time.Sleep(publisher.delay)
+	if atomic.AddInt32(&publisher.calls, 1) <= publisher.failures {
+		return errors.New("Failed")
+	}
+	publisher.content, publisher.fileName, publisher.sentAt = content, fileName, time.Now()
+	return nil
+}
@@ -0,0 +1,281 @@
+package timetracker
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/tommzn/go-log"
+)
+
+// NewReportFormat returns default formats used to write reports.
+func newReportFormat(logger log.Logger) reportFormat {
+	return reportFormat{
+		dateFormat: "2006-01-02",
+		timeFormat: "15:04",
+		holidays:   make(map[Date]Holiday),
+		messages:   messageCatalogs[ENGLISH],
+		logger:     logger,
+	}
+}
+
+// ReportFormat contains settings shared by all report formatters, e.g. date format, timezone and holidays.
+type reportFormat struct {
+
+	// Holidays is a list of public holidays.
+	holidays map[Date]Holiday
+
+	// dateFormat defnines the format a day should be printed in the outut.
+	dateFormat string
+
+	// TimeFormat defines output format for timestamps of time tracking records.
+	timeFormat string
+
+	// Timezone is used to convert timestamps from time tracking records, captured in UTC, to local time.
+	timezone *time.Location
+
+	// Messages contains all labels in language of current report.
+	messages messageCatalog
+
+	logger log.Logger
+}
+
+// WithHolidays will assign give list of holidays for output formatting.
+func (format *reportFormat) WithHolidays(holidays []Holiday) {
+	format.holidays = asHolidayMap(holidays)
+}
+
+// ApplyLocale will use date format, timezone and language of given report.
+func (format *reportFormat) applyLocale(report *MonthlyReport) {
+	format.determineDateFormat(report)
+	format.determineTimezone(report)
+	format.messages = messagesFor(report.Location)
+}
+
+// DetermineDateFormat will apply date format if it has been defined in report locale.
+// Default format is "2006-01-02".
+func (format *reportFormat) determineDateFormat(report *MonthlyReport) {
+	if report.Location.DateFormat != nil {
+		format.dateFormat = *report.Location.DateFormat
+	} else {
+		format.dateFormat = "2006-01-02"
+	}
+}
+
+// DetermineTimezone will assign a timezone to a formatter it it has been defined in report local.
+func (format *reportFormat) determineTimezone(report *MonthlyReport) {
+
+	format.logger.Debug("Report timezone: ", report.Location.Timezone)
+	if report.Location.Timezone != nil {
+		if location, err := time.LoadLocation(*report.Location.Timezone); err == nil {
+			format.logger.Debugf("Time location: %+v", location)
+			format.timezone = location
+			return
+		} else {
+			format.logger.Error("Unable to load location, reason: ", err)
+		}
+	}
+	format.timezone = nil
+}
+
+// AtTimezone returns passed time in a timezone defined for this formatter.
+func (format *reportFormat) atTimezone(t time.Time) time.Time {
+	if format.timezone != nil {
+		return t.In(format.timezone)
+	}
+	return t
+}
+
+// FormatTime applies default time format to given timestamp.
+func (format *reportFormat) formatTime(t time.Time) string {
+	return format.atTimezone(t).Format(format.timeFormat)
+}
+
+// FormatDate applies date format to given day.
+func (format *reportFormat) formatDate(date Date) string {
+	return format.atTimezone(date.AsTime()).Format(format.dateFormat)
+}
+
+// CommentOf returns a comment for given day, description of a holiday or a label for days of illness, vacation, etc.
+func (format *reportFormat) commentOf(day Day) string {
+	if holiday, ok := format.holidays[day.Date]; ok {
+		return holiday.Description
+	}
+	if _, ok := recordTypeFormats()[day.Type]; ok {
+		return format.messages.message(messageKey(day.Type))
+	}
+	return ""
+}
+
+// Message returns label for given key in language of current report.
+func (format *reportFormat) message(key messageKey) string {
+	return format.messages.message(key)
+}
+
+// TableHeaders returns column names of monthly tables: date, start, end, working time, break time and comment.
+func (format *reportFormat) tableHeaders() []string {
+	return []string{
+		format.message(msgDate),
+		format.message(msgStart),
+		format.message(msgEnd),
+		format.message(msgWorkingTime),
+		format.message(msgBreakTime),
+		format.message(msgComment),
+	}
+}
+
+// ReportTitle returns title of a report for given month, e.g. "Time Tracking Report 2022-01".
+func (format *reportFormat) reportTitle(report *MonthlyReport) string {
+	return fmt.Sprintf(format.message(msgReportTitle), report.Year, report.Month)
+}
+
+// ComplianceComment returns "OK" if there're no violations of compliance rules,
+// otherwise number of violations and all violated rules.
+func (format *reportFormat) complianceComment(summary ReportSummary) string {
+
+	if summary.IsCompliant() {
+		return format.message(msgCompliant)
+	}
+	rules := []string{}
+	violatedRules := make(map[ComplianceRule]bool)
+	for _, violation := range summary.Violations {
+		if !violatedRules[violation.Rule] {
+			violatedRules[violation.Rule] = true
+			rules = append(rules, string(violation.Rule))
+		}
+	}
+	return fmt.Sprintf(format.message(msgViolations), len(summary.Violations), strings.Join(rules, ", "))
+}
+
+// RecordTypeFormat defines background color used to highlight days of a specific type.
+// Labels for record types are defined in message catalogs.
+type recordTypeFormat struct {
+	color string
+}
+
+// RecordTypeFormats returns output formats for all record types which should be highlighted in a report.
+func recordTypeFormats() map[RecordType]recordTypeFormat {
+	return map[RecordType]recordTypeFormat{
+		ILLNESS:        {color: "#ff6600"},
+		VACATION:       {color: "#669900"},
+		BUSINESS_TRIP:  {color: "#6699cc"},
+		TRAINING:       {color: "#9999ff"},
+		COMP_TIME_OFF:  {color: "#ffcc00"},
+		SPECIAL_LEAVE:  {color: "#66cccc"},
+		PARENTAL_LEAVE: {color: "#cc99cc"},
+	}
+}
+
+// ForEachDayOfMonth walks through all calendar days of a report month and calls passed function for each day.
+// Days without any time tracking records are passed as empty working days.
+func forEachDayOfMonth(report *MonthlyReport, fn func(Day)) {
+
+	days := generateIndexMap(report.Days)
+	calendarDay := time.Date(report.Year, time.Month(report.Month), 1, 0, 0, 0, 0, time.UTC)
+	lastDayOfMonth := calendarDay.AddDate(0, 1, -1)
+	for isDayBeforeOrEqual(calendarDay, lastDayOfMonth) {
+
+		date := asDate(calendarDay)
+		if day, ok := days[date]; ok {
+			fn(day)
+		} else {
+			fn(emptyDay(date))
+		}
+		calendarDay = nextDay(calendarDay)
+	}
+}
+
+// FormatDuration returns string representation of given duration in format HH:MM.
+// Negative durations are prefixed with a minus sign.
+func formatDuration(d time.Duration) string {
+	if d < 0 {
+		return "-" + formatDuration(-d)
+	}
+	d = d.Round(time.Minute)
+	h := d / time.Hour
+	d -= h * time.Hour
+	m := d / time.Minute
+	return fmt.Sprintf("%02d:%02d", h, m)
+}
+
+// GenerateIndexMap creates a map where date of a day in used as index.
+// If a date exists multiple times, e.g. a day with events and a filled day of a continuous type, the first one is used.
+func generateIndexMap(days []Day) map[Date]Day {
+	daysMao := make(map[Date]Day)
+	for _, day := range days {
+		if _, ok := daysMao[day.Date]; !ok {
+			daysMao[day.Date] = day
+		}
+	}
+	return daysMao
+}
+
+// EmptyDay returns a working day without time tracking events an no working or break time.
+func emptyDay(date Date) Day {
+	return Day{
+		Date:        date,
+		Type:        WORKDAY,
+		WorkingTime: time.Duration(0),
+		BreakTime:   time.Duration(0),
+		Events:      []TimeTrackingRecord{},
+	}
+}
+
+// Summarize returns a summary of given report. Holidays of this format are not counted as absence days.
+func (format *reportFormat) summarize(report *MonthlyReport) ReportSummary {
+	holidays := []Holiday{}
+	for _, holiday := range format.holidays {
+		holidays = append(holidays, holiday)
+	}
+	return SummarizeMonthlyReportWithHolidays(report, holidays)
+}
+
+// LocationDays is the number of days at a work location with a localized label, e.g. "Home Office (Days)".
+type locationDays struct {
+	workLocation WorkLocation
+	label        string
+	days         int
+}
+
+// WorkLocationDaysOf returns number of days per work location with localized labels. Known work locations are
+// ordered as defined, home office first, other locations follow in alphabetical order. Locations without days are skipped.
+func (format *reportFormat) workLocationDaysOf(workLocationDays map[WorkLocation]int) []locationDays {
+
+	rank := func(workLocation WorkLocation) int {
+		for idx, knownLocation := range []WorkLocation{HOME, OFFICE, REMOTE, CLIENT_SITE} {
+			if workLocation == knownLocation {
+				return idx
+			}
+		}
+		return 4
+	}
+	workLocations := []WorkLocation{}
+	for workLocation, days := range workLocationDays {
+		if days > 0 {
+			workLocations = append(workLocations, workLocation)
+		}
+	}
+	sort.Slice(workLocations, func(i, j int) bool {
+		if rank(workLocations[i]) != rank(workLocations[j]) {
+			return rank(workLocations[i]) < rank(workLocations[j])
+		}
+		return workLocations[i] < workLocations[j]
+	})
+
+	locations := []locationDays{}
+	for _, workLocation := range workLocations {
+		label := fmt.Sprintf("%s (%s)", format.message(messageKey(workLocation)), format.message(msgDays))
+		locations = append(locations, locationDays{workLocation: workLocation, label: label, days: workLocationDays[workLocation]})
+	}
+	return locations
+}
+
+// AsHolidayMap generates a map with date index for passed lost pf holidays.
+func asHolidayMap(holidays []Holiday) map[Date]Holiday {
+	holidayMap := make(map[Date]Holiday)
+	for _, holiday := range holidays {
+		holidayMap[holiday.Date] = holiday
+	}
+	return holidayMap
+}
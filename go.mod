@@ -5,6 +5,7 @@ go 1.19
 require (
 	github.com/aws/aws-sdk-go v1.44.168
 	github.com/calendarific/go-calendarific v0.0.0-20221115171631-30c5173a0a3f
+	github.com/stretchr/testify v1.8.1
 	github.com/tommzn/go-log v1.2.2
 	github.com/tommzn/go-utils v1.0.2
 	github.com/xuri/excelize/v2 v2.6.1
@@ -31,7 +32,6 @@ require (
 	github.com/spf13/jwalterweatherman v1.1.0 // indirect
 	github.com/spf13/pflag v1.0.5 // indirect
 	github.com/spf13/viper v1.7.1 // indirect
-	github.com/stretchr/testify v1.8.1 // indirect
 	github.com/subosito/gotenv v1.2.0 // indirect
 	github.com/tommzn/go-config v1.0.1 // indirect
 	github.com/tommzn/go-secrets v1.0.0 // indirect
@@ -0,0 +1,239 @@
+package timetracker
+
+import (
+	"bytes"
+	"html/template"
+	"os"
+	"strconv"
+
+	log "github.com/tommzn/go-log"
+)
+
+// NewHTMLReportFormatter returns a new formatter to generate a self-contained HTML page for a report.
+func NewHTMLReportFormatter(logger log.Logger) *HTMLReportFormatter {
+	return &HTMLReportFormatter{
+		reportFormat: newReportFormat(logger),
+		theme:        DefaultHTMLTheme(),
+		template:     template.Must(template.New("report").Parse(defaultHTMLTemplate)),
+	}
+}
+
+// HTMLTheme defines fonts and colors of a HTML report.
+type HTMLTheme struct {
+
+	// FontFamily used for the entire report.
+	FontFamily string
+
+	// TextColor is the default font color.
+	TextColor string
+
+	// HeaderColor is the background color of header row.
+	HeaderColor string
+
+	// BorderColor is used for table borders.
+	BorderColor string
+
+	// WeekendColor is the background color of weekend days.
+	WeekendColor string
+
+	// HolidayColor is the background color of holidays. Overwrites weekend color.
+	HolidayColor string
+
+	// TypeColors are background colors for days of illness, vacation, etc.
+	TypeColors map[RecordType]string
+}
+
+// DefaultHTMLTheme returns a theme which uses same colors as Excel reports.
+func DefaultHTMLTheme() HTMLTheme {
+	theme := HTMLTheme{
+		FontFamily:   "Arial, Helvetica, sans-serif",
+		TextColor:    "#000000",
+		HeaderColor:  "#ffffff",
+		BorderColor:  "#000000",
+		WeekendColor: "#FEC7CE",
+		HolidayColor: "#FED7DE",
+		TypeColors:   make(map[RecordType]string),
+	}
+	for recordType, recordTypeFormat := range recordTypeFormats() {
+		theme.TypeColors[recordType] = recordTypeFormat.color
+	}
+	return theme
+}
+
+// HTMLReportFormatter generates HTML pages for reports, e.g. to use them as email body.
+type HTMLReportFormatter struct {
+
+	// ReportFormat contains date/time formats, timezone and holidays.
+	reportFormat
+
+	// Theme defines fonts and colors.
+	theme HTMLTheme
+
+	// Template used to render a report.
+	template *template.Template
+}
+
+// HTMLReportData is passed to a template to render a report.
+type HTMLReportData struct {
+
+	// Title of a report, e.g. "Time Tracking Report 2022-01".
+	Title string
+
+	// Theme defines fonts and colors.
+	Theme HTMLTheme
+
+	// Headers are the column names.
+	Headers []string
+
+	// Rows contains all days of a month.
+	Rows []HTMLReportRow
+
+	// TotalWorkingTime of a month, format HH:MM.
+	TotalWorkingTime string
+
+	// WorkLocationDays contains number of days per work location, e.g. home office days.
+	WorkLocationDays []HTMLSummaryRow
+}
+
+// HTMLSummaryRow is a labelled value below the table of a report.
+type HTMLSummaryRow struct {
+	Label, Value string
+}
+
+// HTMLReportRow contains formatted values of a single day.
+type HTMLReportRow struct {
+	Date, Start, End, WorkingTime, BreakTime, Comment string
+
+	// Color is the background color of a row. Empty for usual working days.
+	Color string
+}
+
+// WithTheme sets fonts and colors used to render a report.
+func (formatter *HTMLReportFormatter) WithTheme(theme HTMLTheme) {
+	formatter.theme = theme
+}
+
+// WithTemplate replaces default template. Passed template will get a HTMLReportData to render a report.
+func (formatter *HTMLReportFormatter) WithTemplate(template *template.Template) {
+	formatter.template = template
+}
+
+// FileExtension returns file extension for HTML files: html.
+func (formatter *HTMLReportFormatter) FileExtension() string {
+	return ".html"
+}
+
+// WriteMonthlyReportToFile will generate a report outout an writes it to given file.
+func (formatter *HTMLReportFormatter) WriteMonthlyReportToFile(report *MonthlyReport, filename string) error {
+
+	buf, err := formatter.WriteMonthlyReportToBuffer(report)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, buf.Bytes(), 0644)
+}
+
+// WriteMonthlyReportToBuffer returns a buffer for gemerated report output.
+func (formatter *HTMLReportFormatter) WriteMonthlyReportToBuffer(report *MonthlyReport) (*bytes.Buffer, error) {
+
+	buf := new(bytes.Buffer)
+	if err := formatter.template.Execute(buf, formatter.reportData(report)); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// ReportData creates all values passed to a template for given report.
+func (formatter *HTMLReportFormatter) reportData(report *MonthlyReport) HTMLReportData {
+
+	formatter.applyLocale(report)
+	data := HTMLReportData{
+		Title:            formatter.reportTitle(report),
+		Theme:            formatter.theme,
+		Headers:          formatter.tableHeaders(),
+		Rows:             []HTMLReportRow{},
+		TotalWorkingTime: formatDuration(report.TotalWorkingTime),
+		WorkLocationDays: []HTMLSummaryRow{},
+	}
+	for _, location := range formatter.workLocationDaysOf(report.WorkLocationDays) {
+		data.WorkLocationDays = append(data.WorkLocationDays, HTMLSummaryRow{Label: location.label, Value: strconv.Itoa(location.days)})
+	}
+	forEachDayOfMonth(report, func(day Day) {
+		data.Rows = append(data.Rows, formatter.rowForDay(day))
+	})
+	return data
+}
+
+// RowForDay returns formatted values and background color for a single day.
+// Background color is applied in same order as in Excel reports: day type, weekend and holiday.
+func (formatter *HTMLReportFormatter) rowForDay(day Day) HTMLReportRow {
+
+	row := HTMLReportRow{
+		Date:        formatter.formatDate(day.Date),
+		WorkingTime: formatDuration(day.WorkingTime),
+		BreakTime:   formatDuration(day.BreakTime),
+		Comment:     formatter.commentOf(day),
+		Color:       formatter.theme.TypeColors[day.Type],
+	}
+	if len(day.Events) > 0 {
+		row.Start = formatter.formatTime(day.Events[0].Timestamp)
+	}
+	if len(day.Events) > 1 {
+		row.End = formatter.formatTime(day.Events[len(day.Events)-1].Timestamp)
+	}
+	if isWeekend(day.Date.AsTime()) {
+		row.Color = formatter.theme.WeekendColor
+	}
+	if _, ok := formatter.holidays[day.Date]; ok {
+		row.Color = formatter.theme.HolidayColor
+	}
+	return row
+}
+
+// DefaultHTMLTemplate renders a report as a table with inline styles, so it can be used as email body, too.
+const defaultHTMLTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+</head>
+<body style="font-family: {{.Theme.FontFamily}}; color: {{.Theme.TextColor}};">
+<h1 style="font-size: 18px;">{{.Title}}</h1>
+<table style="border-collapse: collapse; font-size: 14px;">
+<thead>
+<tr style="background-color: {{.Theme.HeaderColor}};">
+{{- range .Headers}}
+<th style="text-align: left; padding: 2px 8px; border-bottom: 2px solid {{$.Theme.BorderColor}};">{{.}}</th>
+{{- end}}
+</tr>
+</thead>
+<tbody>
+{{- range .Rows}}
+<tr{{if .Color}} style="background-color: {{.Color}};"{{end}}>
+<td style="padding: 2px 8px;">{{.Date}}</td>
+<td style="padding: 2px 8px;">{{.Start}}</td>
+<td style="padding: 2px 8px;">{{.End}}</td>
+<td style="padding: 2px 8px;">{{.WorkingTime}}</td>
+<td style="padding: 2px 8px;">{{.BreakTime}}</td>
+<td style="padding: 2px 8px;">{{.Comment}}</td>
+</tr>
+{{- end}}
+</tbody>
+<tfoot>
+<tr>
+<td colspan="3" style="padding: 2px 8px; border-top: 2px solid {{.Theme.BorderColor}};"></td>
+<td style="padding: 2px 8px; border-top: 2px solid {{.Theme.BorderColor}}; font-weight: bold;">{{.TotalWorkingTime}}</td>
+<td colspan="2" style="padding: 2px 8px; border-top: 2px solid {{.Theme.BorderColor}};"></td>
+</tr>
+{{- range .WorkLocationDays}}
+<tr>
+<td colspan="3" style="padding: 2px 8px;">{{.Label}}</td>
+<td style="padding: 2px 8px;">{{.Value}}</td>
+<td colspan="2" style="padding: 2px 8px;"></td>
+</tr>
+{{- end}}
+</tfoot>
+</table>
+</body>
+</html>
+`
@@ -0,0 +1,63 @@
+package timetracker
+
+import (
+	"html/template"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type HTMLReportFormatterTestSuite struct {
+	suite.Suite
+}
+
+func TestHTMLReportFormatterTestSuite(t *testing.T) {
+	suite.Run(t, new(HTMLReportFormatterTestSuite))
+}
+
+func (suite *HTMLReportFormatterTestSuite) TestGenerateReport() {
+
+	formatter := NewHTMLReportFormatter(loggerForTest())
+	formatter.WithHolidays([]Holiday{Holiday{Date: Date{Year: 2022, Month: 1, Day: 6}, Description: "Heilige Drei Könige"}})
+	report := monthlyReportWithAllTypesForTest()
+
+	buf, err := formatter.WriteMonthlyReportToBuffer(report)
+	suite.Nil(err)
+	output := buf.String()
+	suite.True(strings.HasPrefix(output, "<!DOCTYPE html>"))
+	suite.Equal(31, strings.Count(output, "<td style=\"padding: 2px 8px;\">")/6)
+	suite.Contains(output, "<td style=\"padding: 2px 8px;\">Heilige Drei Könige</td>")
+	suite.Contains(output, "<tr style=\"background-color: #ff6600;\">")
+	suite.Contains(output, "<tr style=\"background-color: #FED7DE;\">")
+	suite.Contains(output, "17:00")
+
+	filename := suite.T().TempDir() + "/report" + formatter.FileExtension()
+	suite.Nil(formatter.WriteMonthlyReportToFile(report, filename))
+	_, err = os.Stat(filename)
+	suite.Nil(err)
+}
+
+func (suite *HTMLReportFormatterTestSuite) TestWorkLocationDays() {
+
+	report := monthlyReportForTest()
+	report.WorkLocationDays = map[WorkLocation]int{HOME: 3}
+	buf, err := NewHTMLReportFormatter(loggerForTest()).WriteMonthlyReportToBuffer(report)
+	suite.Nil(err)
+	suite.Contains(buf.String(), "<td colspan=\"3\" style=\"padding: 2px 8px;\">Homeoffice (Tage)</td>\n<td style=\"padding: 2px 8px;\">3</td>")
+}
+
+func (suite *HTMLReportFormatterTestSuite) TestCustomThemeAndTemplate() {
+
+	formatter := NewHTMLReportFormatter(loggerForTest())
+	theme := DefaultHTMLTheme()
+	theme.TypeColors[ILLNESS] = "#123456"
+	formatter.WithTheme(theme)
+	formatter.WithTemplate(template.Must(template.New("custom").Parse(`{{.Title}}{{range .Rows}}{{if .Color}}|{{.Date}}={{.Color}}{{end}}{{end}}`)))
+
+	buf, err := formatter.WriteMonthlyReportToBuffer(monthlyReportForTest())
+	suite.Nil(err)
+	suite.True(strings.HasPrefix(buf.String(), "Arbeitszeitnachweis 2022-01|"))
+	suite.Contains(buf.String(), "|10.01.2022=#123456|")
+}
@@ -0,0 +1,268 @@
+package timetracker
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/tommzn/go-log"
+)
+
+const (
+
+	// IcsDateTimeFormat is the iCalendar format of a local date with time.
+	icsDateTimeFormat = "20060102T150405"
+
+	// IcsDateFormat is the iCalendar format of all-day events.
+	icsDateFormat = "20060102"
+
+	// IcsMaxLineLength is the max length of a content line, in octets, before it has to be folded.
+	icsMaxLineLength = 75
+)
+
+// NewICSReportFormatter returns a new formatter to generate iCalendar files for reports.
+func NewICSReportFormatter(logger log.Logger) *ICSReportFormatter {
+	return &ICSReportFormatter{
+		reportFormat: newReportFormat(logger),
+	}
+}
+
+// ICSReportFormatter generates iCalendar files, which can be imported into calendar apps.
+// Each pair of working events is written as an event with start and end in timezone of a report,
+// days of illness, vacation and other absences as all-day events and holidays as all-day events, too.
+type ICSReportFormatter struct {
+
+	// ReportFormat contains date/time formats, timezone and holidays.
+	reportFormat
+
+	// OwnerId identifies the person or device a report belongs to, it's part of all event UIDs.
+	ownerId string
+}
+
+// WithOwnerId defines an id of the person or device a report belongs to, e.g. a person id of a team report.
+// It's used in UIDs of all events, so events of different persons don't replace each other in a shared calendar.
+// If not set, device id of the first time tracking record of a report is used.
+func (formatter *ICSReportFormatter) WithOwnerId(ownerId string) {
+	formatter.ownerId = ownerId
+}
+
+// FileExtension returns file extension for iCalendar files: ics.
+func (formatter *ICSReportFormatter) FileExtension() string {
+	return ".ics"
+}
+
+// WriteMonthlyReportToFile will generate a report outout an writes it to given file.
+func (formatter *ICSReportFormatter) WriteMonthlyReportToFile(report *MonthlyReport, filename string) error {
+
+	buf, err := formatter.WriteMonthlyReportToBuffer(report)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, buf.Bytes(), 0644)
+}
+
+// WriteMonthlyReportToBuffer returns a buffer for gemerated report output.
+func (formatter *ICSReportFormatter) WriteMonthlyReportToBuffer(report *MonthlyReport) (*bytes.Buffer, error) {
+
+	formatter.applyLocale(report)
+
+	lines := []string{"BEGIN:VCALENDAR", "VERSION:2.0", "PRODID:-//tommzn//hob-timetracker//EN", "CALSCALE:GREGORIAN"}
+	if formatter.timezone != nil {
+		lines = append(lines, icsTimezone(formatter.timezone, report.Year)...)
+	}
+
+	timestamp := time.Now().UTC().Format(icsDateTimeFormat) + "Z"
+	ownerId := formatter.ownerIdOf(report)
+	var absence *icsAbsence
+	forEachDayOfMonth(report, func(day Day) {
+
+		for idx, eventPair := range eventPairsOf(day) {
+			if len(eventPair) < 2 {
+				continue
+			}
+			lines = append(lines, "BEGIN:VEVENT",
+				icsUid(ownerId, fmt.Sprintf("%s-work-%d", day.Date.AsTime().Format(icsDateFormat), idx)),
+				"DTSTAMP:"+timestamp,
+				formatter.icsDateTime("DTSTART", eventPair[0].Timestamp),
+				formatter.icsDateTime("DTEND", eventPair[1].Timestamp),
+				"SUMMARY:"+icsEscape(formatter.summaryOf(day)),
+				"END:VEVENT")
+		}
+
+		if absence != nil && absence.recordType != day.Type {
+			lines = append(lines, absence.event(formatter.message(messageKey(absence.recordType)), ownerId, timestamp)...)
+			absence = nil
+		}
+		if isAbsenceType(day.Type) {
+			if absence == nil {
+				absence = &icsAbsence{recordType: day.Type, start: day.Date}
+			}
+			absence.end = day.Date
+		}
+	})
+	if absence != nil {
+		lines = append(lines, absence.event(formatter.message(messageKey(absence.recordType)), ownerId, timestamp)...)
+	}
+
+	holidays := []Holiday{}
+	for _, holiday := range formatter.holidays {
+		if holiday.Date.Year == report.Year && holiday.Date.Month == report.Month {
+			holidays = append(holidays, holiday)
+		}
+	}
+	sort.Slice(holidays, func(i, j int) bool { return holidays[i].Date.Before(holidays[j].Date) })
+	for _, holiday := range holidays {
+		lines = append(lines, "BEGIN:VEVENT",
+			icsUid(ownerId, holiday.Date.AsTime().Format(icsDateFormat)+"-holiday"),
+			"DTSTAMP:"+timestamp,
+			"DTSTART;VALUE=DATE:"+holiday.Date.AsTime().Format(icsDateFormat),
+			"DTEND;VALUE=DATE:"+holiday.Date.AsTime().AddDate(0, 0, 1).Format(icsDateFormat),
+			"SUMMARY:"+icsEscape(holiday.Description),
+			"TRANSP:TRANSPARENT",
+			"END:VEVENT")
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	buf := new(bytes.Buffer)
+	for _, line := range lines {
+		buf.WriteString(icsFold(line))
+	}
+	return buf, nil
+}
+
+// SummaryOf returns summary of working events for given day, label of the day type for business trips
+// and trainings, otherwise a default label for work.
+func (formatter *ICSReportFormatter) summaryOf(day Day) string {
+	if _, ok := recordTypeFormats()[day.Type]; ok {
+		return formatter.message(messageKey(day.Type))
+	}
+	return formatter.message(msgWork)
+}
+
+// OwnerIdOf returns id defined by WithOwnerId or device id of the first time tracking record of given report.
+func (formatter *ICSReportFormatter) ownerIdOf(report *MonthlyReport) string {
+	if formatter.ownerId != "" {
+		return formatter.ownerId
+	}
+	for _, day := range report.Days {
+		for _, event := range day.Events {
+			if event.DeviceId != "" {
+				return event.DeviceId
+			}
+		}
+	}
+	return ""
+}
+
+// IcsDateTime returns a property for given timestamp, in local time with a reference to the timezone
+// of current report or in UTC if there's no timezone.
+func (formatter *ICSReportFormatter) icsDateTime(name string, timestamp time.Time) string {
+	if formatter.timezone != nil {
+		return fmt.Sprintf("%s;TZID=%s:%s", name, formatter.timezone.String(), timestamp.In(formatter.timezone).Format(icsDateTimeFormat))
+	}
+	return fmt.Sprintf("%s:%sZ", name, timestamp.UTC().Format(icsDateTimeFormat))
+}
+
+// IcsAbsence is a span of days with same absence type, e.g. vacation.
+type icsAbsence struct {
+	recordType RecordType
+	start, end Date
+}
+
+// Event returns an all-day event for this span. End date of all-day events is exclusive.
+func (absence *icsAbsence) event(summary, ownerId, timestamp string) []string {
+	return []string{"BEGIN:VEVENT",
+		icsUid(ownerId, fmt.Sprintf("%s-%s", absence.start.AsTime().Format(icsDateFormat), absence.recordType)),
+		"DTSTAMP:" + timestamp,
+		"DTSTART;VALUE=DATE:" + absence.start.AsTime().Format(icsDateFormat),
+		"DTEND;VALUE=DATE:" + absence.end.AsTime().AddDate(0, 0, 1).Format(icsDateFormat),
+		"SUMMARY:" + icsEscape(summary),
+		"TRANSP:TRANSPARENT",
+		"END:VEVENT"}
+}
+
+// IsAbsenceType returns true for all types of days without work, e.g. illness or vacation.
+func isAbsenceType(recordType RecordType) bool {
+	_, ok := recordTypeFormats()[recordType]
+	return ok && !isWorkingType(recordType)
+}
+
+// IcsTimezone returns a VTIMEZONE component for given location with all offset transitions of given year.
+// First observance contains the offset at the beginning of the year, so all events of this year are covered.
+func icsTimezone(location *time.Location, year int) []string {
+
+	lines := []string{"BEGIN:VTIMEZONE", "TZID:" + location.String()}
+	observance := func(timestamp time.Time, offsetFrom int, start string) {
+		component := "STANDARD"
+		if timestamp.IsDST() {
+			component = "DAYLIGHT"
+		}
+		name, offsetTo := timestamp.Zone()
+		lines = append(lines, "BEGIN:"+component, "DTSTART:"+start,
+			"TZOFFSETFROM:"+icsOffset(offsetFrom), "TZOFFSETTO:"+icsOffset(offsetTo),
+			"TZNAME:"+name, "END:"+component)
+	}
+
+	timestamp := time.Date(year, time.January, 1, 0, 0, 0, 0, location)
+	_, offset := timestamp.Zone()
+	observance(timestamp, offset, "19700101T000000")
+	for end := timestamp.AddDate(1, 0, 0); timestamp.Before(end); timestamp = timestamp.Add(time.Hour) {
+		if _, nextOffset := timestamp.Add(time.Hour).Zone(); nextOffset != offset {
+			transition := timestamp.Add(time.Hour)
+			observance(transition, offset, transition.UTC().Add(time.Duration(offset)*time.Second).Format(icsDateTimeFormat))
+			offset = nextOffset
+		}
+	}
+	return append(lines, "END:VTIMEZONE")
+}
+
+// IcsOffset returns given offset in seconds as UTC offset, e.g. +0100.
+func icsOffset(offset int) string {
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, offset/3600, offset%3600/60)
+}
+
+// IcsUid returns an UID property for an event with given id of a person or device. Characters other than letters,
+// digits, dashes and dots are replaced in an owner id, because it's used as part of the domain of an UID.
+func icsUid(ownerId, eventId string) string {
+	if ownerId == "" {
+		return fmt.Sprintf("UID:%s@hob-timetracker", eventId)
+	}
+	ownerId = strings.Map(func(r rune) rune {
+		if r == '-' || r == '.' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, ownerId)
+	return fmt.Sprintf("UID:%s@%s.hob-timetracker", eventId, ownerId)
+}
+
+// IcsEscape escapes backslashes, semicolons, commas and newlines in text values.
+func icsEscape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(value)
+}
+
+// IcsFold splits a content line into lines of max 75 octets, continuation lines start with a space.
+// Lines are never split within a multi-byte character.
+func icsFold(line string) string {
+	folded := new(strings.Builder)
+	length := 0
+	for _, r := range line {
+		size := len(string(r))
+		if length+size > icsMaxLineLength {
+			folded.WriteString("\r\n ")
+			length = 1
+		}
+		folded.WriteRune(r)
+		length += size
+	}
+	folded.WriteString("\r\n")
+	return folded.String()
+}
@@ -0,0 +1,112 @@
+package timetracker
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ICSReportFormatterTestSuite struct {
+	suite.Suite
+}
+
+func TestICSReportFormatterTestSuite(t *testing.T) {
+	suite.Run(t, new(ICSReportFormatterTestSuite))
+}
+
+func (suite *ICSReportFormatterTestSuite) TestGenerateReport() {
+
+	formatter := NewICSReportFormatter(loggerForTest())
+	formatter.WithHolidays([]Holiday{
+		Holiday{Date: Date{Year: 2022, Month: 1, Day: 6}, Description: "Heilige Drei Könige"},
+		Holiday{Date: Date{Year: 2022, Month: 2, Day: 28}, Description: "Rosenmontag"},
+	})
+	report := monthlyReportWithAllTypesForTest()
+
+	buf, err := formatter.WriteMonthlyReportToBuffer(report)
+	suite.Nil(err)
+	content := buf.String()
+	suite.True(strings.HasPrefix(content, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	suite.True(strings.HasSuffix(content, "END:VCALENDAR\r\n"))
+	suite.Equal(strings.Count(content, "BEGIN:VEVENT"), strings.Count(content, "END:VEVENT"))
+
+	suite.Contains(content, "TZID:Europe/Berlin\r\n")
+	suite.Contains(content, "BEGIN:DAYLIGHT\r\nDTSTART:20220327T020000\r\nTZOFFSETFROM:+0100\r\nTZOFFSETTO:+0200\r\n")
+	suite.Contains(content, "BEGIN:STANDARD\r\nDTSTART:20221030T030000\r\nTZOFFSETFROM:+0200\r\nTZOFFSETTO:+0100\r\n")
+
+	suite.Contains(content, "DTSTART;TZID=Europe/Berlin:20220101T090000\r\nDTEND;TZID=Europe/Berlin:20220101T173000\r\nSUMMARY:Arbeit\r\n")
+	suite.Contains(content, "DTSTART;TZID=Europe/Berlin:20220117T080000\r\nDTEND;TZID=Europe/Berlin:20220117T174500\r\nSUMMARY:Dienstreise\r\n")
+	suite.Contains(content, "DTSTART;VALUE=DATE:20220110\r\nDTEND;VALUE=DATE:20220111\r\nSUMMARY:Krankheit\r\n")
+	suite.Contains(content, "DTSTART;VALUE=DATE:20220111\r\nDTEND;VALUE=DATE:20220113\r\nSUMMARY:Urlaub\r\n")
+	suite.Contains(content, "DTSTART;VALUE=DATE:20220106\r\nDTEND;VALUE=DATE:20220107\r\nSUMMARY:Heilige Drei Könige\r\n")
+	suite.NotContains(content, "Rosenmontag")
+	suite.Contains(content, "DTSTART;VALUE=DATE:20220119\r\nDTEND;VALUE=DATE:20220120\r\nSUMMARY:Freizeitausgleich\r\n")
+	suite.Contains(content, "DTSTART;VALUE=DATE:20220121\r\nDTEND;VALUE=DATE:20220122\r\nSUMMARY:Elternzeit\r\n")
+	suite.Equal(8, strings.Count(content, "BEGIN:VEVENT"))
+
+	filename := suite.T().TempDir() + "/report" + formatter.FileExtension()
+	suite.Nil(formatter.WriteMonthlyReportToFile(report, filename))
+	_, err = os.Stat(filename)
+	suite.Nil(err)
+}
+
+func (suite *ICSReportFormatterTestSuite) TestUidContainsOwner() {
+
+	formatter := NewICSReportFormatter(loggerForTest())
+	formatter.WithHolidays([]Holiday{Holiday{Date: Date{Year: 2022, Month: 1, Day: 6}, Description: "Heilige Drei Könige"}})
+	report := monthlyReportForTest()
+
+	buf, err := formatter.WriteMonthlyReportToBuffer(report)
+	suite.Nil(err)
+	suite.Contains(buf.String(), "UID:20220101-work-0@hob-timetracker\r\n")
+
+	report.Days[0].Events[0].DeviceId = "Device 01"
+	buf, err = formatter.WriteMonthlyReportToBuffer(report)
+	suite.Nil(err)
+	suite.Contains(buf.String(), "UID:20220101-work-0@Device_01.hob-timetracker\r\n")
+
+	formatter.WithOwnerId("Person01")
+	buf, err = formatter.WriteMonthlyReportToBuffer(report)
+	suite.Nil(err)
+	content := buf.String()
+	suite.Contains(content, "UID:20220101-work-0@Person01.hob-timetracker\r\n")
+	suite.Contains(content, "UID:20220110-illness@Person01.hob-timetracker\r\n")
+	suite.Contains(content, "UID:20220106-holiday@Person01.hob-timetracker\r\n")
+	suite.Equal(strings.Count(content, "BEGIN:VEVENT"), strings.Count(content, "@Person01.hob-timetracker"))
+}
+
+func (suite *ICSReportFormatterTestSuite) TestWithoutTimezone() {
+
+	formatter := NewICSReportFormatter(loggerForTest())
+	report := monthlyReportForTest()
+	report.Location.Timezone = nil
+
+	buf, err := formatter.WriteMonthlyReportToBuffer(report)
+	suite.Nil(err)
+	suite.NotContains(buf.String(), "BEGIN:VTIMEZONE")
+	suite.Contains(buf.String(), "DTSTART:20220101T080000Z\r\nDTEND:20220101T163000Z\r\n")
+}
+
+func (suite *ICSReportFormatterTestSuite) TestTimezoneWithoutTransitions() {
+
+	location, err := time.LoadLocation("Asia/Tokyo")
+	suite.Nil(err)
+	lines := icsTimezone(location, 2022)
+	suite.Equal([]string{"BEGIN:VTIMEZONE", "TZID:Asia/Tokyo", "BEGIN:STANDARD", "DTSTART:19700101T000000",
+		"TZOFFSETFROM:+0900", "TZOFFSETTO:+0900", "TZNAME:JST", "END:STANDARD", "END:VTIMEZONE"}, lines)
+}
+
+func (suite *ICSReportFormatterTestSuite) TestFoldAndEscape() {
+
+	suite.Equal(`Office\, Room 1\; Floor 2\\3\nBuilding A`, icsEscape("Office, Room 1; Floor 2\\3\nBuilding A"))
+
+	line := "SUMMARY:" + strings.Repeat("ä", 40)
+	folded := icsFold(line)
+	foldedLines := strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n ")
+	suite.Len(foldedLines, 2)
+	suite.LessOrEqual(len(foldedLines[0]), 75)
+	suite.Equal(line, strings.Join(foldedLines, ""))
+}
@@ -0,0 +1,254 @@
+package timetracker
+
+import (
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	log "github.com/tommzn/go-log"
+)
+
+// ImportResult contains all time tracking records read by an importer and the diff to existing records.
+type ImportResult struct {
+
+	// DryRun is true if records have not been added to a repository.
+	DryRun bool
+
+	// Records contains all time tracking records read from an import source.
+	Records []TimeTrackingRecord
+
+	// Added contains all records which have been added, or would have been added in dry-run mode.
+	// Added records have a key assigned by used repository, except in dry-run mode.
+	Added []TimeTrackingRecord
+
+	// Skipped contains all records which already exist.
+	Skipped []TimeTrackingRecord
+
+	// Overlapping contains all working records which have been rejected, because their start and end
+	// overlap with working time which already exists at same day.
+	Overlapping []TimeTrackingRecord
+}
+
+// NewRecordImporter returns an importer which reads time tracking records from exports of other time tracking tools
+// with given parser, e.g. a Toggl or Clockify CSV export.
+func NewRecordImporter(repository TimeTrackingRecordRepository, parser RecordParser, logger log.Logger) *RecordImporter {
+	return &RecordImporter{
+		recordImport: newRecordImport(repository, logger),
+		parser:       parser,
+	}
+}
+
+// RecordImporter adds time tracking records read by a parser to a repository.
+type RecordImporter struct {
+
+	// RecordImport adds records to a repository and provides dry-run mode.
+	recordImport
+
+	// Parser reads time tracking records from an export.
+	parser RecordParser
+
+	// Locale defines the timezone of timestamps in an export.
+	locale Locale
+}
+
+// WithLocale defines the timezone of timestamps in an export. Timestamps are treated as UTC if there's no timezone.
+func (importer *RecordImporter) WithLocale(locale Locale) {
+	importer.locale = locale
+}
+
+// ImportFile reads all entries of given export file and adds time tracking records for given device.
+func (importer *RecordImporter) ImportFile(deviceId, filename string) (*ImportResult, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return importer.Import(deviceId, file)
+}
+
+// Import reads all entries of an export from given reader and adds time tracking records for given device.
+func (importer *RecordImporter) Import(deviceId string, reader io.Reader) (*ImportResult, error) {
+	records, err := importer.parser.Parse(reader, locationOf(importer.locale))
+	if err != nil {
+		return nil, err
+	}
+	return importer.addRecords(deviceId, records)
+}
+
+// NewRecordImport returns settings shared by all importers.
+func newRecordImport(repository TimeTrackingRecordRepository, logger log.Logger) recordImport {
+	return recordImport{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+// RecordImport adds imported time tracking records to a repository, skipping all existing records.
+type recordImport struct {
+
+	// Repository to list existing and to add new time tracking records.
+	repository TimeTrackingRecordRepository
+
+	// DryRun, if enabled, records will not be added to a repository.
+	dryRun bool
+
+	logger log.Logger
+}
+
+// WithDryRun enables dry-run mode. An import will return all records which would be added without adding them.
+func (recordImport *recordImport) WithDryRun() {
+	recordImport.dryRun = true
+}
+
+// AddRecords adds given records for passed device to a repository. A record is skipped if a record with
+// same type already exists at same minute. Records of working types are added as start/end pairs, a pair
+// is rejected if it overlaps with existing working time of a day, because it would break pairing of existing records.
+func (recordImport *recordImport) addRecords(deviceId string, records []TimeTrackingRecord) (*ImportResult, error) {
+
+	result := &ImportResult{
+		DryRun:      recordImport.dryRun,
+		Records:     records,
+		Added:       []TimeTrackingRecord{},
+		Skipped:     []TimeTrackingRecord{},
+		Overlapping: []TimeTrackingRecord{},
+	}
+	if len(records) == 0 {
+		return result, nil
+	}
+
+	sort.Slice(records, func(i, j int) bool { return records[i].Timestamp.Before(records[j].Timestamp) })
+	start := records[0].Timestamp.UTC().Truncate(24*time.Hour).AddDate(0, 0, -1)
+	end := records[len(records)-1].Timestamp.UTC().Truncate(24*time.Hour).AddDate(0, 0, 2).Add(-1 * time.Second)
+	existingRecords, err := recordImport.repository.ListRecords(deviceId, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, chunk := range importChunksOf(records) {
+
+		newRecords := []TimeTrackingRecord{}
+		for _, record := range chunk {
+			record.DeviceId = deviceId
+			if containsRecord(existingRecords, record) {
+				result.Skipped = append(result.Skipped, record)
+			} else {
+				newRecords = append(newRecords, record)
+			}
+		}
+		if len(newRecords) == 0 {
+			continue
+		}
+		if isWorkingType(chunk[0].Type) && !keepsWorkingPairs(existingRecords, chunk, newRecords) {
+			result.Overlapping = append(result.Overlapping, newRecords...)
+			continue
+		}
+
+		for _, record := range newRecords {
+			if !recordImport.dryRun {
+				if record, err = recordImport.repository.Add(record); err != nil {
+					return result, err
+				}
+			}
+			existingRecords = append(existingRecords, record)
+			result.Added = append(result.Added, record)
+		}
+	}
+	recordImport.logger.Infof("Imported %d records for %s, added: %d, skipped: %d, overlapping: %d, dry-run: %t",
+		len(result.Records), deviceId, len(result.Added), len(result.Skipped), len(result.Overlapping), result.DryRun)
+	return result, nil
+}
+
+// ImportChunksOf splits given, sorted records into chunks which are added together. Working records of a day
+// are paired in chunks of start and end, all other records are added one by one.
+func importChunksOf(records []TimeTrackingRecord) [][]TimeTrackingRecord {
+
+	chunks := [][]TimeTrackingRecord{}
+	workingRecords := []TimeTrackingRecord{}
+	for idx, record := range records {
+		if isWorkingType(record.Type) {
+			workingRecords = append(workingRecords, record)
+		} else {
+			chunks = append(chunks, []TimeTrackingRecord{record})
+		}
+		if len(workingRecords) > 0 && (idx == len(records)-1 || !isSameDay(records[idx+1].Timestamp, workingRecords[0].Timestamp)) {
+			chunks = append(chunks, splitTimeTrackingRecords(workingRecords, 2)...)
+			workingRecords = []TimeTrackingRecord{}
+		}
+	}
+	return chunks
+}
+
+// KeepsWorkingPairs returns true if adding new records of given start/end pair doesn't change pairing of
+// existing working records of same day and start and end of imported pair remain a pair.
+func keepsWorkingPairs(existingRecords, importedPair, newRecords []TimeTrackingRecord) bool {
+
+	location := importedPair[0].Timestamp.Location()
+	existingWorkingRecords := []TimeTrackingRecord{}
+	for _, record := range workingEvents(existingRecords) {
+		if isSameDay(record.Timestamp.In(location), importedPair[0].Timestamp) {
+			existingWorkingRecords = append(existingWorkingRecords, record)
+		}
+	}
+	mergedRecords := append(append([]TimeTrackingRecord{}, existingWorkingRecords...), newRecords...)
+	sortRecords(existingWorkingRecords)
+	sortRecords(mergedRecords)
+
+	pairs := splitTimeTrackingRecords(existingWorkingRecords, 2)
+	if len(importedPair) == 2 {
+		pairs = append(pairs, importedPair)
+	}
+	for _, pair := range pairs {
+		if len(pair) == 2 && !isPairOf(mergedRecords, pair[0], pair[1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsPairOf returns true if given start and end are paired in passed, sorted list of records. Timestamps are compared by minute.
+func isPairOf(records []TimeTrackingRecord, start, end TimeTrackingRecord) bool {
+	for idx := 0; idx < len(records)-1; idx += 2 {
+		if isSameMinute(records[idx].Timestamp, start.Timestamp) {
+			return isSameMinute(records[idx+1].Timestamp, end.Timestamp)
+		}
+	}
+	return false
+}
+
+// SortRecords sorts given records by timestamp.
+func sortRecords(records []TimeTrackingRecord) {
+	sort.Slice(records, func(i, j int) bool { return records[i].Timestamp.Before(records[j].Timestamp) })
+}
+
+// IsSameDay returns true if both timestamps are at same date, in location of first timestamp.
+func isSameDay(t1, t2 time.Time) bool {
+	y1, m1, d1 := t1.Date()
+	y2, m2, d2 := t2.In(t1.Location()).Date()
+	return y1 == y2 && m1 == m2 && d1 == d2
+}
+
+// IsSameMinute returns true if both timestamps are within same minute.
+func isSameMinute(t1, t2 time.Time) bool {
+	return t1.Truncate(time.Minute).Equal(t2.Truncate(time.Minute))
+}
+
+// LocationOf returns the timezone of given locale or UTC if there's no valid timezone.
+func locationOf(locale Locale) *time.Location {
+	if locale.Timezone != nil {
+		if location, err := time.LoadLocation(*locale.Timezone); err == nil {
+			return location
+		}
+	}
+	return time.UTC
+}
+
+// ContainsRecord returns true if passed list contains a record with same type at same minute as given record.
+func containsRecord(records []TimeTrackingRecord, record TimeTrackingRecord) bool {
+	for _, existingRecord := range records {
+		if existingRecord.Type == record.Type && isSameMinute(existingRecord.Timestamp, record.Timestamp) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package timetracker
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RecordImporterTestSuite struct {
+	suite.Suite
+}
+
+func TestRecordImporterTestSuite(t *testing.T) {
+	suite.Run(t, new(RecordImporterTestSuite))
+}
+
+func (suite *RecordImporterTestSuite) TestImport() {
+
+	repository := NewLocaLRepository()
+	repository.Add(TimeTrackingRecord{DeviceId: deviceIdForTest(), Type: WORKDAY, Timestamp: asTime("2022-03-01T07:30:00")})
+	importer := NewRecordImporter(repository, NewTogglParser(), loggerForTest())
+	importer.WithLocale(localeForTest())
+
+	result, err := importer.Import(deviceIdForTest(), strings.NewReader(suite.togglExportForTest()))
+	suite.Nil(err)
+	suite.False(result.DryRun)
+	suite.Len(result.Records, 4)
+	suite.Len(result.Added, 3)
+	suite.Len(result.Skipped, 1)
+	suite.Equal(asTime("2022-03-01T07:30:00"), result.Skipped[0].Timestamp)
+	suite.NotEqual("", result.Added[0].Key)
+	suite.Equal(deviceIdForTest(), result.Added[0].DeviceId)
+
+	records, err := repository.ListRecords(deviceIdForTest(), asTime("2022-03-01T00:00:00"), asTime("2022-03-02T23:59:59"))
+	suite.Nil(err)
+	suite.Len(records, 4)
+
+	filename := suite.T().TempDir() + "/toggl.csv"
+	suite.Nil(os.WriteFile(filename, []byte(suite.togglExportForTest()), 0644))
+	result, err = importer.ImportFile(deviceIdForTest(), filename)
+	suite.Nil(err)
+	suite.Len(result.Added, 0)
+	suite.Len(result.Skipped, 4)
+
+	_, err = importer.ImportFile(deviceIdForTest(), filename+".missing")
+	suite.NotNil(err)
+}
+
+func (suite *RecordImporterTestSuite) TestDryRun() {
+
+	repository := NewLocaLRepository()
+	importer := NewRecordImporter(repository, NewTogglParser(), loggerForTest())
+	importer.WithLocale(localeForTest())
+	importer.WithDryRun()
+
+	result, err := importer.Import(deviceIdForTest(), strings.NewReader(suite.togglExportForTest()))
+	suite.Nil(err)
+	suite.True(result.DryRun)
+	suite.Len(result.Added, 4)
+	suite.Equal("", result.Added[0].Key)
+
+	records, err := repository.ListRecords(deviceIdForTest(), asTime("2022-03-01T00:00:00"), asTime("2022-03-02T23:59:59"))
+	suite.Nil(err)
+	suite.Len(records, 0)
+}
+
+func (suite *RecordImporterTestSuite) TestOverlappingRecords() {
+
+	repository := NewLocaLRepository()
+	repository.Add(TimeTrackingRecord{DeviceId: deviceIdForTest(), Type: WORKDAY, Timestamp: asTime("2022-03-01T08:00:00")})
+	repository.Add(TimeTrackingRecord{DeviceId: deviceIdForTest(), Type: WORKDAY, Timestamp: asTime("2022-03-01T17:00:00")})
+	repository.Add(TimeTrackingRecord{DeviceId: deviceIdForTest(), Type: WORKDAY, Timestamp: asTime("2022-03-02T08:00:00")})
+	importer := newRecordImport(repository, loggerForTest())
+
+	result, err := importer.addRecords(deviceIdForTest(), []TimeTrackingRecord{
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-03-01T09:00:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-03-01T12:00:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-03-01T17:30:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-03-01T18:30:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-03-02T13:00:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-03-02T15:00:00")},
+	})
+	suite.Nil(err)
+	suite.Len(result.Added, 2)
+	suite.Equal(asTime("2022-03-01T17:30:00"), result.Added[0].Timestamp)
+	suite.Len(result.Skipped, 0)
+	suite.Len(result.Overlapping, 4)
+	suite.Equal(asTime("2022-03-01T09:00:00"), result.Overlapping[0].Timestamp)
+	suite.Equal(asTime("2022-03-02T13:00:00"), result.Overlapping[2].Timestamp)
+
+	records, err := repository.ListRecords(deviceIdForTest(), asTime("2022-03-01T00:00:00"), asTime("2022-03-01T23:59:59"))
+	suite.Nil(err)
+	report, err := NewReportCalulator(records, localeForTest()).MonthlyReport(2022, 3, WORKDAY)
+	suite.Nil(err)
+	suite.Len(report.Days[0].Events, 4)
+	suite.Equal(asTime("2022-03-01T17:00:00"), report.Days[0].Events[1].Timestamp)
+}
+
+func (suite *RecordImporterTestSuite) TestInvalidExport() {
+
+	importer := NewRecordImporter(NewLocaLRepository(), NewTogglParser(), loggerForTest())
+	_, err := importer.Import(deviceIdForTest(), strings.NewReader("User,Project\nJane,Website\n"))
+	suite.NotNil(err)
+}
+
+func (suite *RecordImporterTestSuite) togglExportForTest() string {
+	return "User,Email,Client,Project,Task,Description,Billable,Start date,Start time,End date,End time,Duration,Tags,Amount ()\n" +
+		"Jane,jane@example.com,ACME,Website,,Layout,Yes,2022-03-01,08:30:00,2022-03-01,17:00:00,08:30:00,,\n" +
+		"Jane,jane@example.com,ACME,Website,,Review,Yes,2022-03-02,09:00:00,2022-03-02,16:00:00,07:00:00,,\n"
+}
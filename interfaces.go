@@ -2,6 +2,7 @@ package timetracker
 
 import (
 	"bytes"
+	"io"
 	"time"
 )
 
@@ -30,6 +31,28 @@ type TimeTrackingRecordManager interface {
 	Delete(string) error
 }
 
+// TimeTrackingRecordRepository is used to list existing and to add new time tracking records, e.g. by importers.
+type TimeTrackingRecordRepository interface {
+	TimeTracker
+	TimeTrackingRecordManager
+}
+
+// RecordParser reads time tracking records from an export of another time tracking tool.
+type RecordParser interface {
+
+	// Parse returns time tracking records for all entries of given export. Timestamps in an export
+	// are in local time of passed location and converted to UTC.
+	Parse(io.Reader, *time.Location) ([]TimeTrackingRecord, error)
+}
+
+// WorkLocationProvider is used to get default work location of a device.
+type WorkLocationProvider interface {
+
+	// WorkLocationOf returns default work location for given device id.
+	// Returns an empty work location if there's no default for a device.
+	WorkLocationOf(string) WorkLocation
+}
+
 // ReportCalculator creates a time tracking summary based on captured records.
 type ReportCalculator interface {
 
@@ -40,6 +63,14 @@ type ReportCalculator interface {
 	MonthlyReport(int, int, RecordType) (*MonthlyReport, error)
 }
 
+// YearlyReportCalculator is a report calculator which creates yearly reports, too.
+type YearlyReportCalculator interface {
+	ReportCalculator
+
+	// YearlyReport calculates monthly reports for all month of given year.
+	YearlyReport(int, RecordType) (*YearlyReport, error)
+}
+
 // ReportFormatter generates an output for passed reports.
 type ReportFormatter interface {
 
@@ -56,6 +87,38 @@ type ReportFormatter interface {
 	FileExtension() string
 }
 
+// TeamReportFormatter generates an output for team reports.
+type TeamReportFormatter interface {
+
+	// WithHolidays will assign give list of holidays for output formatting.
+	WithHolidays(holidays []Holiday)
+
+	// WriteTeamReportToFile will generate a team report output an writes it to given file.
+	WriteTeamReportToFile(*TeamReport, string) error
+
+	// WriteTeamReportToBuffer returns a buffer for gemerated team report output.
+	WriteTeamReportToBuffer(*TeamReport) (*bytes.Buffer, error)
+
+	// FileExtension returns an extenstion for a report file.
+	FileExtension() string
+}
+
+// YearlyReportFormatter generates an output for yearly reports.
+type YearlyReportFormatter interface {
+
+	// WithHolidays will assign give list of holidays for output formatting.
+	WithHolidays(holidays []Holiday)
+
+	// WriteYearlyReportToFile will generate a yearly report output an writes it to given file.
+	WriteYearlyReportToFile(*YearlyReport, string) error
+
+	// WriteYearlyReportToBuffer returns a buffer for gemerated yearly report output.
+	WriteYearlyReportToBuffer(*YearlyReport) (*bytes.Buffer, error)
+
+	// FileExtension returns an extenstion for a report file.
+	FileExtension() string
+}
+
 // ReportPublisher sends given report to a defined target.
 type ReportPublisher interface {
 
@@ -63,9 +126,56 @@ type ReportPublisher interface {
 	Send([]byte, string) error
 }
 
+// ReportSummaryPublisher is a publisher which uses the monthly report of a published output, e.g. to render
+// a summary of a report into an email.
+type ReportSummaryPublisher interface {
+	ReportPublisher
+
+	// WithMonthlyReport assigns the report which belongs to outputs published by Send.
+	// It's kept until another report is assigned, so a publisher must not be shared by concurrent sends of different reports.
+	WithMonthlyReport(*MonthlyReport)
+
+	// SendMonthlyReport publishes given report data together with the monthly report it belongs to.
+	// A passed monthly report is not stored, so it's safe to use by concurrent sends.
+	SendMonthlyReport([]byte, string, *MonthlyReport) error
+}
+
+// ReportLinkProvider returns links to published reports, e.g. to add them to notifications.
+type ReportLinkProvider interface {
+
+	// ReportLink returns a link to a report published with given file name.
+	ReportLink(string) (string, error)
+}
+
 // Calendar is used to get holidays or non-working days.
 type Calendar interface {
 
 	// GetHolidays returns a list of holiday for given year and month.
 	GetHolidays(int, int) ([]Holiday, error)
 }
+
+// PersonRegistry is used to get persons and the devices they use.
+type PersonRegistry interface {
+
+	// GetPerson returns a person for given id.
+	GetPerson(string) (*Person, error)
+}
+
+// DeviceRegistry is used to manage devices and their settings.
+type DeviceRegistry interface {
+
+	// AddDevice creates a new device. Returns an error if a device with same id already exists.
+	AddDevice(Device) error
+
+	// GetDevice returns a device for given id.
+	GetDevice(string) (*Device, error)
+
+	// ListDevices returns all existing devices.
+	ListDevices() ([]Device, error)
+
+	// UpdateDevice will replace settings of an existing device.
+	UpdateDevice(Device) error
+
+	// DeleteDevice will remove device with given id.
+	DeleteDevice(string) error
+}
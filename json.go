@@ -0,0 +1,422 @@
+package timetracker
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/tommzn/go-log"
+)
+
+// JSONReportSchemaVersion is the version of the schema used to write JSON reports, see docs/json-report-schema.md.
+// Minor versions are backwards compatible, a decoder accepts all reports with same major version.
+const JSONReportSchemaVersion = "1.2"
+
+// NewJSONReportFormatter returns a new formatter to generate JSON output for a report.
+func NewJSONReportFormatter(logger log.Logger) *JSONReportFormatter {
+	return &JSONReportFormatter{reportFormat: newReportFormat(logger), indent: "    "}
+}
+
+// JSONReportFormatter writes reports as JSON documents.
+type JSONReportFormatter struct {
+
+	// ReportFormat contains timezone and holidays.
+	reportFormat
+
+	// Indent used to format JSON output. Output will be written in compact format if indent is empty.
+	indent string
+}
+
+// WithIndent sets indent used to format JSON output. Pass an empty string for compact output.
+func (formatter *JSONReportFormatter) WithIndent(indent string) {
+	formatter.indent = indent
+}
+
+// FileExtension returns file extension for JSON files: json.
+func (formatter *JSONReportFormatter) FileExtension() string {
+	return ".json"
+}
+
+// WriteMonthlyReportToFile will generate a report outout an writes it to given file.
+func (formatter *JSONReportFormatter) WriteMonthlyReportToFile(report *MonthlyReport, filename string) error {
+
+	buf, err := formatter.WriteMonthlyReportToBuffer(report)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, buf.Bytes(), 0644)
+}
+
+// WriteMonthlyReportToBuffer returns a buffer for gemerated report output.
+func (formatter *JSONReportFormatter) WriteMonthlyReportToBuffer(report *MonthlyReport) (*bytes.Buffer, error) {
+
+	formatter.applyLocale(report)
+
+	buf := new(bytes.Buffer)
+	encoder := json.NewEncoder(buf)
+	encoder.SetIndent("", formatter.indent)
+	if err := encoder.Encode(formatter.toJSONReport(report)); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// DecodeJSONReport reads a report written by a JSON report formatter. Returns the report and all holidays
+// included in JSON output. Timestamps of time tracking records are converted to UTC.
+func DecodeJSONReport(reader io.Reader) (*MonthlyReport, []Holiday, error) {
+
+	jsonReport := &jsonReport{}
+	if err := json.NewDecoder(reader).Decode(jsonReport); err != nil {
+		return nil, nil, err
+	}
+	if majorVersion(jsonReport.SchemaVersion) != majorVersion(JSONReportSchemaVersion) {
+		return nil, nil, fmt.Errorf("Unsupported schema version: %s", jsonReport.SchemaVersion)
+	}
+	return jsonReport.toMonthlyReport()
+}
+
+// JSONReport is the root element of a JSON report.
+type jsonReport struct {
+	SchemaVersion    string               `json:"schemaVersion"`
+	Year             int                  `json:"year"`
+	Month            int                  `json:"month"`
+	Locale           jsonLocale           `json:"locale"`
+	Totals           jsonTotals           `json:"totals"`
+	WorkLocationDays map[WorkLocation]int `json:"workLocationDays"`
+	Days             []jsonDay            `json:"days"`
+	Holidays         []jsonHoliday        `json:"holidays"`
+}
+
+// JSONLocale contains locale settings of a report.
+type jsonLocale struct {
+	Country         string                  `json:"country"`
+	Language        Language                `json:"language,omitempty"`
+	Timezone        *string                 `json:"timezone,omitempty"`
+	DateFormat      *string                 `json:"dateFormat,omitempty"`
+	DefaultWorkTime jsonDuration            `json:"defaultWorkTime"`
+	Breaks          []jsonBreak             `json:"breaks"`
+	WorkSchedule    map[string]jsonDuration `json:"workSchedule,omitempty"`
+}
+
+// JSONBreak is a break which has to be applied after given working time.
+type jsonBreak struct {
+	After    jsonDuration `json:"after"`
+	Duration jsonDuration `json:"duration"`
+}
+
+// JSONTotals contains total working time, target working time and overtime of a month.
+type jsonTotals struct {
+	WorkingTime       jsonDuration `json:"workingTime"`
+	TargetWorkingTime jsonDuration `json:"targetWorkingTime"`
+	Overtime          jsonDuration `json:"overtime"`
+}
+
+// JSONDay is a single day of a report.
+type jsonDay struct {
+	Date         string       `json:"date"`
+	Type         RecordType   `json:"type"`
+	WorkLocation WorkLocation `json:"workLocation,omitempty"`
+	WorkingTime  jsonDuration `json:"workingTime"`
+	BreakTime    jsonDuration `json:"breakTime"`
+	TargetTime   jsonDuration `json:"targetTime"`
+	Events       []jsonEvent  `json:"events"`
+}
+
+// JSONEvent is a single time tracking record.
+type jsonEvent struct {
+	Key          string       `json:"key,omitempty"`
+	DeviceId     string       `json:"deviceId,omitempty"`
+	Type         RecordType   `json:"type"`
+	Timestamp    string       `json:"timestamp"`
+	Estimated    bool         `json:"estimated"`
+	WorkLocation WorkLocation `json:"workLocation,omitempty"`
+	Project      string       `json:"project,omitempty"`
+}
+
+// JSONHoliday is a single public holiday.
+type jsonHoliday struct {
+	Date        string `json:"date"`
+	Description string `json:"description"`
+}
+
+// JSONDuration is a duration in ISO 8601 format and in minutes.
+type jsonDuration struct {
+	ISO8601 string `json:"iso8601"`
+	Minutes int64  `json:"minutes"`
+}
+
+// ToJSONReport converts given report to its JSON representation.
+func (formatter *JSONReportFormatter) toJSONReport(report *MonthlyReport) jsonReport {
+
+	jsonReport := jsonReport{
+		SchemaVersion: JSONReportSchemaVersion,
+		Year:          report.Year,
+		Month:         report.Month,
+		Locale:        toJSONLocale(report.Location),
+		Totals: jsonTotals{
+			WorkingTime:       toJSONDuration(report.TotalWorkingTime),
+			TargetWorkingTime: toJSONDuration(report.TargetWorkingTime),
+			Overtime:          toJSONDuration(report.Overtime),
+		},
+		WorkLocationDays: report.WorkLocationDays,
+		Days:             []jsonDay{},
+		Holidays:         []jsonHoliday{},
+	}
+	if jsonReport.WorkLocationDays == nil {
+		jsonReport.WorkLocationDays = make(map[WorkLocation]int)
+	}
+
+	for _, day := range report.Days {
+		jsonDay := jsonDay{
+			Date:         day.Date.String(),
+			Type:         day.Type,
+			WorkLocation: day.WorkLocation,
+			WorkingTime:  toJSONDuration(day.WorkingTime),
+			BreakTime:    toJSONDuration(day.BreakTime),
+			TargetTime:   toJSONDuration(day.TargetTime),
+			Events:       []jsonEvent{},
+		}
+		for _, event := range day.Events {
+			jsonDay.Events = append(jsonDay.Events, jsonEvent{
+				Key:          event.Key,
+				DeviceId:     event.DeviceId,
+				Type:         event.Type,
+				Timestamp:    formatter.atTimezone(event.Timestamp).Format(time.RFC3339),
+				Estimated:    event.Estimated,
+				WorkLocation: event.WorkLocation,
+				Project:      event.Project,
+			})
+		}
+		jsonReport.Days = append(jsonReport.Days, jsonDay)
+	}
+
+	forEachDayOfMonth(report, func(day Day) {
+		if holiday, ok := formatter.holidays[day.Date]; ok {
+			jsonReport.Holidays = append(jsonReport.Holidays, jsonHoliday{Date: holiday.Date.String(), Description: holiday.Description})
+		}
+	})
+	return jsonReport
+}
+
+// ToJSONLocale converts given locale to its JSON representation.
+func toJSONLocale(locale Locale) jsonLocale {
+
+	jsonLocale := jsonLocale{
+		Country:         locale.Country,
+		Language:        locale.Language,
+		Timezone:        locale.Timezone,
+		DateFormat:      locale.DateFormat,
+		DefaultWorkTime: toJSONDuration(locale.DefaultWorkTime),
+		Breaks:          []jsonBreak{},
+	}
+	for after, duration := range locale.Breaks {
+		jsonLocale.Breaks = append(jsonLocale.Breaks, jsonBreak{After: toJSONDuration(after), Duration: toJSONDuration(duration)})
+	}
+	sort.Slice(jsonLocale.Breaks, func(i, j int) bool { return jsonLocale.Breaks[i].After.Minutes < jsonLocale.Breaks[j].After.Minutes })
+	if len(locale.WorkSchedule) > 0 {
+		jsonLocale.WorkSchedule = make(map[string]jsonDuration)
+		for weekday, duration := range locale.WorkSchedule {
+			jsonLocale.WorkSchedule[strings.ToLower(weekday.String())] = toJSONDuration(duration)
+		}
+	}
+	return jsonLocale
+}
+
+// ToMonthlyReport converts a JSON report back to a monthly report.
+func (jsonReport *jsonReport) toMonthlyReport() (*MonthlyReport, []Holiday, error) {
+
+	location, err := jsonReport.Locale.toLocale()
+	if err != nil {
+		return nil, nil, err
+	}
+	report := &MonthlyReport{
+		Year:             jsonReport.Year,
+		Month:            jsonReport.Month,
+		Location:         location,
+		Days:             []Day{},
+		WorkLocationDays: make(map[WorkLocation]int),
+	}
+	for workLocation, days := range jsonReport.WorkLocationDays {
+		report.WorkLocationDays[workLocation] = days
+	}
+	if report.TotalWorkingTime, err = jsonReport.Totals.WorkingTime.toDuration(); err != nil {
+		return nil, nil, err
+	}
+	if report.TargetWorkingTime, err = jsonReport.Totals.TargetWorkingTime.toDuration(); err != nil {
+		return nil, nil, err
+	}
+	if report.Overtime, err = jsonReport.Totals.Overtime.toDuration(); err != nil {
+		return nil, nil, err
+	}
+
+	for _, jsonDay := range jsonReport.Days {
+		day, err := jsonDay.toDay()
+		if err != nil {
+			return nil, nil, err
+		}
+		report.Days = append(report.Days, day)
+	}
+
+	holidays := []Holiday{}
+	for _, jsonHoliday := range jsonReport.Holidays {
+		date, err := parseDate(jsonHoliday.Date)
+		if err != nil {
+			return nil, nil, err
+		}
+		holidays = append(holidays, Holiday{Date: date, Description: jsonHoliday.Description})
+	}
+	return report, holidays, nil
+}
+
+// ToLocale converts JSON locale settings back to a locale.
+func (jsonLocale jsonLocale) toLocale() (Locale, error) {
+
+	var err error
+	locale := Locale{
+		Country:    jsonLocale.Country,
+		Language:   jsonLocale.Language,
+		Timezone:   jsonLocale.Timezone,
+		DateFormat: jsonLocale.DateFormat,
+		Breaks:     make(map[time.Duration]time.Duration),
+	}
+	if locale.DefaultWorkTime, err = jsonLocale.DefaultWorkTime.toDuration(); err != nil {
+		return locale, err
+	}
+	for _, jsonBreak := range jsonLocale.Breaks {
+		after, err := jsonBreak.After.toDuration()
+		if err != nil {
+			return locale, err
+		}
+		if locale.Breaks[after], err = jsonBreak.Duration.toDuration(); err != nil {
+			return locale, err
+		}
+	}
+	if len(jsonLocale.WorkSchedule) > 0 {
+		locale.WorkSchedule = make(WorkSchedule)
+		for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+			if jsonDuration, ok := jsonLocale.WorkSchedule[strings.ToLower(weekday.String())]; ok {
+				if locale.WorkSchedule[weekday], err = jsonDuration.toDuration(); err != nil {
+					return locale, err
+				}
+			}
+		}
+	}
+	return locale, nil
+}
+
+// ToDay converts a JSON day back to a day.
+func (jsonDay jsonDay) toDay() (Day, error) {
+
+	var err error
+	day := Day{Type: jsonDay.Type, WorkLocation: jsonDay.WorkLocation, Events: []TimeTrackingRecord{}}
+	if day.Date, err = parseDate(jsonDay.Date); err != nil {
+		return day, err
+	}
+	if day.WorkingTime, err = jsonDay.WorkingTime.toDuration(); err != nil {
+		return day, err
+	}
+	if day.BreakTime, err = jsonDay.BreakTime.toDuration(); err != nil {
+		return day, err
+	}
+	if day.TargetTime, err = jsonDay.TargetTime.toDuration(); err != nil {
+		return day, err
+	}
+	for _, jsonEvent := range jsonDay.Events {
+		timestamp, err := time.Parse(time.RFC3339, jsonEvent.Timestamp)
+		if err != nil {
+			return day, err
+		}
+		day.Events = append(day.Events, TimeTrackingRecord{
+			Key:          jsonEvent.Key,
+			DeviceId:     jsonEvent.DeviceId,
+			Type:         jsonEvent.Type,
+			Timestamp:    timestamp.UTC(),
+			Estimated:    jsonEvent.Estimated,
+			WorkLocation: jsonEvent.WorkLocation,
+			Project:      jsonEvent.Project,
+		})
+	}
+	return day, nil
+}
+
+// ToJSONDuration converts given duration to ISO 8601 format and minutes.
+func toJSONDuration(d time.Duration) jsonDuration {
+	return jsonDuration{ISO8601: formatISO8601Duration(d), Minutes: int64(d.Round(time.Minute).Minutes())}
+}
+
+// ToDuration parses ISO 8601 value of a JSON duration.
+func (jsonDuration jsonDuration) toDuration() (time.Duration, error) {
+	return parseISO8601Duration(jsonDuration.ISO8601)
+}
+
+// FormatISO8601Duration returns given duration in ISO 8601 format, e.g. PT7H30M.
+func formatISO8601Duration(d time.Duration) string {
+
+	if d == 0 {
+		return "PT0S"
+	}
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	value := sign + "PT"
+	if hours := d / time.Hour; hours > 0 {
+		value += fmt.Sprintf("%dH", hours)
+		d -= hours * time.Hour
+	}
+	if minutes := d / time.Minute; minutes > 0 {
+		value += fmt.Sprintf("%dM", minutes)
+		d -= minutes * time.Minute
+	}
+	if seconds := d / time.Second; seconds > 0 {
+		value += fmt.Sprintf("%dS", seconds)
+	}
+	return value
+}
+
+// Iso8601DurationPattern matches durations in ISO 8601 format with hours, minutes and seconds.
+var iso8601DurationPattern = regexp.MustCompile(`^(-)?PT(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?$`)
+
+// ParseISO8601Duration parses a duration in ISO 8601 format, e.g. PT7H30M.
+// Supports hours, minutes and seconds, only.
+func parseISO8601Duration(value string) (time.Duration, error) {
+
+	matches := iso8601DurationPattern.FindStringSubmatch(value)
+	if matches == nil || value == "PT" || value == "-PT" {
+		return 0, errors.New("Invalid ISO 8601 duration: " + value)
+	}
+	d := time.Duration(0)
+	for idx, unit := range []time.Duration{time.Hour, time.Minute, time.Second} {
+		if matches[idx+2] != "" {
+			n, _ := strconv.ParseInt(matches[idx+2], 10, 64)
+			d += time.Duration(n) * unit
+		}
+	}
+	if matches[1] == "-" {
+		d = -d
+	}
+	return d, nil
+}
+
+// ParseDate converts a date in format YYYY-MM-DD.
+func parseDate(value string) (Date, error) {
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return Date{}, err
+	}
+	return asDate(t), nil
+}
+
+// MajorVersion returns major version of given schema version, e.g. 1 for 1.2.
+func majorVersion(version string) string {
+	return strings.SplitN(version, ".", 2)[0]
+}
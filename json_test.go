@@ -0,0 +1,92 @@
+package timetracker
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type JSONReportFormatterTestSuite struct {
+	suite.Suite
+}
+
+func TestJSONReportFormatterTestSuite(t *testing.T) {
+	suite.Run(t, new(JSONReportFormatterTestSuite))
+}
+
+func (suite *JSONReportFormatterTestSuite) TestGenerateReport() {
+
+	formatter := NewJSONReportFormatter(loggerForTest())
+	formatter.WithHolidays([]Holiday{Holiday{Date: Date{Year: 2022, Month: 1, Day: 6}, Description: "Heilige Drei Könige"}})
+	report := monthlyReportWithAllTypesForTest()
+
+	buf, err := formatter.WriteMonthlyReportToBuffer(report)
+	suite.Nil(err)
+
+	var output map[string]interface{}
+	suite.Nil(json.Unmarshal(buf.Bytes(), &output))
+	suite.Equal(JSONReportSchemaVersion, output["schemaVersion"])
+	totals := output["totals"].(map[string]interface{})
+	suite.Equal(map[string]interface{}{"iso8601": "PT17H", "minutes": float64(1020)}, totals["workingTime"])
+	days := output["days"].([]interface{})
+	suite.Len(days, len(report.Days))
+	events := days[0].(map[string]interface{})["events"].([]interface{})
+	suite.Equal("2022-01-01T09:00:00+01:00", events[0].(map[string]interface{})["timestamp"])
+	suite.Len(output["holidays"], 1)
+}
+
+func (suite *JSONReportFormatterTestSuite) TestDecodeReport() {
+
+	formatter := NewJSONReportFormatter(loggerForTest())
+	holidays := []Holiday{Holiday{Date: Date{Year: 2022, Month: 1, Day: 6}, Description: "Heilige Drei Könige"}}
+	formatter.WithHolidays(holidays)
+	report := monthlyReportForTest()
+	report.Location.WorkSchedule = workScheduleForTest()
+	report.Overtime = -90 * time.Minute
+	report.WorkLocationDays = map[WorkLocation]int{HOME: 1}
+
+	buf, err := formatter.WriteMonthlyReportToBuffer(report)
+	suite.Nil(err)
+
+	decodedReport, decodedHolidays, err := DecodeJSONReport(buf)
+	suite.Nil(err)
+	suite.Equal(holidays, decodedHolidays)
+	suite.Equal(report.Location, decodedReport.Location)
+	suite.Equal(report.TotalWorkingTime, decodedReport.TotalWorkingTime)
+	suite.Equal(report.Overtime, decodedReport.Overtime)
+	suite.Equal(report.WorkLocationDays, decodedReport.WorkLocationDays)
+	suite.Len(decodedReport.Days, len(report.Days))
+	suite.Equal(report.Days[0].Events[1].Timestamp, decodedReport.Days[0].Events[1].Timestamp)
+	suite.Equal(time.UTC, decodedReport.Days[0].Events[1].Timestamp.Location())
+
+	_, _, err = DecodeJSONReport(strings.NewReader(`{"schemaVersion": "2.0"}`))
+	suite.NotNil(err)
+	_, _, err = DecodeJSONReport(strings.NewReader(`{"schemaVersion": "1.0", "totals": {"workingTime": {"iso8601": "8h"}}}`))
+	suite.NotNil(err)
+}
+
+func (suite *JSONReportFormatterTestSuite) TestISO8601Durations() {
+
+	durations := map[time.Duration]string{
+		0:                             "PT0S",
+		7*time.Hour + 30*time.Minute:  "PT7H30M",
+		45 * time.Minute:              "PT45M",
+		-90 * time.Minute:             "-PT1H30M",
+		8*time.Hour + 15*time.Second:  "PT8H15S",
+		100*time.Hour + 1*time.Minute: "PT100H1M",
+	}
+	for d, value := range durations {
+		suite.Equal(value, formatISO8601Duration(d))
+		parsed, err := parseISO8601Duration(value)
+		suite.Nil(err)
+		suite.Equal(d, parsed)
+	}
+
+	for _, value := range []string{"PT", "P1D", "7H", "PT7.5H"} {
+		_, err := parseISO8601Duration(value)
+		suite.NotNil(err)
+	}
+}
@@ -15,7 +15,13 @@ func NewLocaLRepository() *LocaLRepository {
 
 // LocaLRepository is an in memory time tracker.
 type LocaLRepository struct {
-	Records map[string]map[Date][]TimeTrackingRecord
+	Records       map[string]map[Date][]TimeTrackingRecord
+	workLocations WorkLocationProvider
+}
+
+// WithWorkLocations applies given provider to assign default work locations of devices to new WORKDAY records.
+func (repo *LocaLRepository) WithWorkLocations(provider WorkLocationProvider) {
+	repo.workLocations = provider
 }
 
 // Capture will create a time tracking record with passed type at time this method has been called.
@@ -39,6 +45,7 @@ func (repo *LocaLRepository) Captured(deviceId string, recordType RecordType, ti
 		Timestamp: timestamp,
 		Estimated: false,
 	}
+	record = withDefaultWorkLocation(record, repo.workLocations)
 	record.Key = repo.recordKey(deviceId, record.Timestamp, len(repo.Records[deviceId][date]))
 	repo.Records[deviceId][date] = append(repo.Records[deviceId][date], record)
 	return nil
@@ -78,6 +85,7 @@ func (repo *LocaLRepository) ListRecords(deviceId string, start time.Time, end t
 func (repo *LocaLRepository) Add(record TimeTrackingRecord) (TimeTrackingRecord, error) {
 
 	record.Timestamp = record.Timestamp.UTC().Round(time.Second)
+	record = withDefaultWorkLocation(record, repo.workLocations)
 	deviceId := record.DeviceId
 	date := asDate(record.Timestamp)
 	if _, ok := repo.Records[deviceId]; !ok {
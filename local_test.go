@@ -89,6 +89,30 @@ func (suite *LocalRepositoryTestSuite) TestRecordCrudActions() {
 	suite.Nil(repo.Delete(record1.Key))
 }
 
+func (suite *LocalRepositoryTestSuite) TestDefaultWorkLocation() {
+
+	repo := NewLocaLRepository()
+	repo.WithWorkLocations(WorkLocations{"Device01": HOME})
+
+	suite.Nil(repo.Captured("Device01", WORKDAY, asTime("2022-02-01T08:00:00")))
+	suite.Nil(repo.Captured("Device01", ILLNESS, asTime("2022-02-02T08:00:00")))
+	suite.Nil(repo.Captured("Device02", WORKDAY, asTime("2022-02-01T08:00:00")))
+	record, err := repo.Add(TimeTrackingRecord{DeviceId: "Device01", Type: WORKDAY, Timestamp: asTime("2022-02-03T08:00:00"), WorkLocation: OFFICE})
+	suite.Nil(err)
+	suite.Equal(OFFICE, record.WorkLocation)
+
+	records, err := repo.ListRecords("Device01", asTime("2022-02-01T00:00:00"), asTime("2022-02-02T23:59:59"))
+	suite.Nil(err)
+	suite.Len(records, 2)
+	suite.Equal(HOME, records[0].WorkLocation)
+	suite.Equal(WorkLocation(""), records[1].WorkLocation)
+
+	records2, err2 := repo.ListRecords("Device02", asTime("2022-02-01T00:00:00"), asTime("2022-02-01T23:59:59"))
+	suite.Nil(err2)
+	suite.Len(records2, 1)
+	suite.Equal(WorkLocation(""), records2[0].WorkLocation)
+}
+
 func prepareRecords(repo *LocaLRepository, deviceId string) {
 
 	durations := []time.Duration{
@@ -0,0 +1,294 @@
+package timetracker
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Language is a ISO 639-1 language code used to select labels in report outputs.
+type Language string
+
+const (
+	ENGLISH Language = "en"
+	GERMAN  Language = "de"
+)
+
+// MessageKey identifies a label in a message catalog.
+type messageKey string
+
+const (
+	msgDate              messageKey = "date"
+	msgStart             messageKey = "start"
+	msgEnd               messageKey = "end"
+	msgWorkingTime       messageKey = "working_time"
+	msgBreakTime         messageKey = "break_time"
+	msgComment           messageKey = "comment"
+	msgTotal             messageKey = "total"
+	msgName              messageKey = "name"
+	msgTargetTime        messageKey = "target_time"
+	msgOvertime          messageKey = "overtime"
+	msgAbsenceDays       messageKey = "absence_days"
+	msgCompliance        messageKey = "compliance"
+	msgCompliant         messageKey = "compliant"
+	msgViolations        messageKey = "violations"
+	msgOverview          messageKey = "overview"
+	msgMember            messageKey = "member"
+	msgReportTitle       messageKey = "report_title"
+	msgTotalWorkingTime  messageKey = "total_working_time"
+	msgTargetWorkingTime messageKey = "target_working_time"
+	msgHolidays          messageKey = "holidays"
+	msgEmployee          messageKey = "employee"
+	msgSupervisor        messageKey = "supervisor"
+	msgSignature         messageKey = "signature"
+	msgWeekday           messageKey = "weekday"
+	msgBreaks            messageKey = "breaks"
+	msgDelta             messageKey = "delta"
+	msgProject           messageKey = "project"
+	msgWorkLocation      messageKey = "work_location"
+	msgEstimated         messageKey = "estimated"
+	msgYes               messageKey = "yes"
+	msgEvents            messageKey = "events"
+	msgSheet             messageKey = "sheet"
+	msgTimestamp         messageKey = "timestamp"
+	msgType              messageKey = "type"
+	msgKey               messageKey = "key"
+	msgDevice            messageKey = "device"
+	msgSummary           messageKey = "summary"
+	msgDays              messageKey = "days"
+	msgHoliday           messageKey = "holiday"
+	msgWork              messageKey = "work"
+	msgOpenReport        messageKey = "open_report"
+	msgMonth             messageKey = "month"
+)
+
+// MessageCatalog contains labels for all messages keys in a single language.
+type messageCatalog map[messageKey]string
+
+// MessageCatalogs contains labels for all supported languages.
+// Labels for record types, work locations, weekdays and months use lower case names as message keys, e.g. "illness" or "monday".
+var messageCatalogs = map[Language]messageCatalog{
+	ENGLISH: {
+		msgDate:                           "Date",
+		msgStart:                          "Start",
+		msgEnd:                            "End",
+		msgWorkingTime:                    "WorkingTime",
+		msgBreakTime:                      "BreakTime",
+		msgComment:                        "Comment",
+		msgTotal:                          "Total",
+		msgName:                           "Name",
+		msgTargetTime:                     "TargetTime",
+		msgOvertime:                       "Overtime",
+		msgAbsenceDays:                    "AbsenceDays",
+		msgCompliance:                     "Compliance",
+		msgCompliant:                      "OK",
+		msgViolations:                     "%d violation(s): %s",
+		msgOverview:                       "Overview",
+		msgMember:                         "Member",
+		msgReportTitle:                    "Time Tracking Report %04d-%02d",
+		msgTotalWorkingTime:               "Total WorkingTime",
+		msgTargetWorkingTime:              "Target WorkingTime",
+		msgHolidays:                       "Holidays",
+		msgEmployee:                       "Employee",
+		msgSupervisor:                     "Supervisor",
+		msgSignature:                      "Signature",
+		msgWeekday:                        "Weekday",
+		msgBreaks:                         "Breaks",
+		msgDelta:                          "Delta",
+		msgProject:                        "Project",
+		msgWorkLocation:                   "Location",
+		msgEstimated:                      "Estimated",
+		msgYes:                            "Yes",
+		msgEvents:                         "Events",
+		msgSheet:                          "Sheet",
+		msgTimestamp:                      "Timestamp",
+		msgType:                           "Type",
+		msgKey:                            "Key",
+		msgDevice:                         "Device",
+		msgSummary:                        "Summary",
+		msgDays:                           "Days",
+		msgMonth:                          "Month",
+		msgHoliday:                        "Holiday",
+		msgWork:                           "Work",
+		msgOpenReport:                     "Open Report",
+		messageKey(HOME):                  "Home Office",
+		messageKey(OFFICE):                "Office",
+		messageKey(REMOTE):                "Remote",
+		messageKey(CLIENT_SITE):           "Client Site",
+		messageKey(ILLNESS):               "Illness",
+		messageKey(VACATION):              "Vacation",
+		messageKey(BUSINESS_TRIP):         "Business Trip",
+		messageKey(TRAINING):              "Training",
+		messageKey(COMP_TIME_OFF):         "Comp Time Off",
+		messageKey(SPECIAL_LEAVE):         "Special Leave",
+		messageKey(PARENTAL_LEAVE):        "Parental Leave",
+		weekdayMessageKey(time.Monday):    "Monday",
+		weekdayMessageKey(time.Tuesday):   "Tuesday",
+		weekdayMessageKey(time.Wednesday): "Wednesday",
+		weekdayMessageKey(time.Thursday):  "Thursday",
+		weekdayMessageKey(time.Friday):    "Friday",
+		weekdayMessageKey(time.Saturday):  "Saturday",
+		weekdayMessageKey(time.Sunday):    "Sunday",
+		monthMessageKey(time.January):     "January",
+		monthMessageKey(time.February):    "February",
+		monthMessageKey(time.March):       "March",
+		monthMessageKey(time.April):       "April",
+		monthMessageKey(time.May):         "May",
+		monthMessageKey(time.June):        "June",
+		monthMessageKey(time.July):        "July",
+		monthMessageKey(time.August):      "August",
+		monthMessageKey(time.September):   "September",
+		monthMessageKey(time.October):     "October",
+		monthMessageKey(time.November):    "November",
+		monthMessageKey(time.December):    "December",
+	},
+	GERMAN: {
+		msgDate:                           "Datum",
+		msgStart:                          "Beginn",
+		msgEnd:                            "Ende",
+		msgWorkingTime:                    "Arbeitszeit",
+		msgBreakTime:                      "Pause",
+		msgComment:                        "Bemerkung",
+		msgTotal:                          "Summe",
+		msgName:                           "Name",
+		msgTargetTime:                     "Sollzeit",
+		msgOvertime:                       "Überstunden",
+		msgAbsenceDays:                    "Abwesenheitstage",
+		msgCompliance:                     "Arbeitszeitgesetz",
+		msgCompliant:                      "OK",
+		msgViolations:                     "%d Verstoß/Verstöße: %s",
+		msgOverview:                       "Übersicht",
+		msgMember:                         "Teammitglied",
+		msgReportTitle:                    "Arbeitszeitnachweis %04d-%02d",
+		msgTotalWorkingTime:               "Gesamtarbeitszeit",
+		msgTargetWorkingTime:              "Sollarbeitszeit",
+		msgHolidays:                       "Feiertage",
+		msgEmployee:                       "Mitarbeiter",
+		msgSupervisor:                     "Vorgesetzter",
+		msgSignature:                      "Unterschrift",
+		msgWeekday:                        "Wochentag",
+		msgBreaks:                         "Pausenzeiten",
+		msgDelta:                          "Differenz",
+		msgProject:                        "Projekt",
+		msgWorkLocation:                   "Arbeitsort",
+		msgEstimated:                      "Geschätzt",
+		msgYes:                            "Ja",
+		msgEvents:                         "Ereignisse",
+		msgSheet:                          "Tabelle",
+		msgTimestamp:                      "Zeitpunkt",
+		msgType:                           "Typ",
+		msgKey:                            "Schlüssel",
+		msgDevice:                         "Gerät",
+		msgSummary:                        "Zusammenfassung",
+		msgDays:                           "Tage",
+		msgMonth:                          "Monat",
+		msgHoliday:                        "Feiertag",
+		msgWork:                           "Arbeit",
+		msgOpenReport:                     "Bericht öffnen",
+		messageKey(HOME):                  "Homeoffice",
+		messageKey(OFFICE):                "Büro",
+		messageKey(REMOTE):                "Mobil",
+		messageKey(CLIENT_SITE):           "Kunde",
+		messageKey(ILLNESS):               "Krankheit",
+		messageKey(VACATION):              "Urlaub",
+		messageKey(BUSINESS_TRIP):         "Dienstreise",
+		messageKey(TRAINING):              "Schulung",
+		messageKey(COMP_TIME_OFF):         "Freizeitausgleich",
+		messageKey(SPECIAL_LEAVE):         "Sonderurlaub",
+		messageKey(PARENTAL_LEAVE):        "Elternzeit",
+		weekdayMessageKey(time.Monday):    "Montag",
+		weekdayMessageKey(time.Tuesday):   "Dienstag",
+		weekdayMessageKey(time.Wednesday): "Mittwoch",
+		weekdayMessageKey(time.Thursday):  "Donnerstag",
+		weekdayMessageKey(time.Friday):    "Freitag",
+		weekdayMessageKey(time.Saturday):  "Samstag",
+		weekdayMessageKey(time.Sunday):    "Sonntag",
+		monthMessageKey(time.January):     "Januar",
+		monthMessageKey(time.February):    "Februar",
+		monthMessageKey(time.March):       "März",
+		monthMessageKey(time.April):       "April",
+		monthMessageKey(time.May):         "Mai",
+		monthMessageKey(time.June):        "Juni",
+		monthMessageKey(time.July):        "Juli",
+		monthMessageKey(time.August):      "August",
+		monthMessageKey(time.September):   "September",
+		monthMessageKey(time.October):     "Oktober",
+		monthMessageKey(time.November):    "November",
+		monthMessageKey(time.December):    "Dezember",
+	},
+}
+
+// LanguageOf returns language defined in given locale. Localized labels are opt-in, so english is used
+// if there's no or an unsupported language, independent of a country of a locale.
+func languageOf(locale Locale) Language {
+
+	language := Language(strings.ToLower(string(locale.Language)))
+	if _, ok := messageCatalogs[language]; ok {
+		return language
+	}
+	return ENGLISH
+}
+
+// MessagesFor returns the message catalog for language of given locale.
+func messagesFor(locale Locale) messageCatalog {
+	return messageCatalogs[languageOf(locale)]
+}
+
+// Message returns label for given key. Falls back to english label, or to the key itself, if a label is missing.
+func (catalog messageCatalog) message(key messageKey) string {
+	if label, ok := catalog[key]; ok {
+		return label
+	}
+	if label, ok := messageCatalogs[ENGLISH][key]; ok {
+		return label
+	}
+	return string(key)
+}
+
+// Weekday returns the name of given day of a week.
+func (catalog messageCatalog) weekday(weekday time.Weekday) string {
+	return catalog.message(weekdayMessageKey(weekday))
+}
+
+// WeekdayMessageKey returns message key for a day of the week, e.g. "monday".
+func weekdayMessageKey(weekday time.Weekday) messageKey {
+	return messageKey(strings.ToLower(weekday.String()))
+}
+
+// Month returns the name of given month.
+func (catalog messageCatalog) month(month time.Month) string {
+	return catalog.message(monthMessageKey(month))
+}
+
+// MonthMessageKey returns message key for a month, e.g. "january".
+func monthMessageKey(month time.Month) messageKey {
+	return messageKey(strings.ToLower(month.String()))
+}
+
+// MessageKeyOf returns the message key for given label in any supported language. Case is ignored.
+// Labels are unique, but keys are looked up in a fixed order to get the same key for a label in each call.
+func messageKeyOf(label string) (messageKey, bool) {
+	label = strings.ToLower(strings.TrimSpace(label))
+	if label == "" {
+		return "", false
+	}
+	for _, language := range []Language{ENGLISH, GERMAN} {
+		catalog := messageCatalogs[language]
+		for _, key := range catalog.sortedKeys() {
+			if strings.ToLower(catalog[key]) == label {
+				return key, true
+			}
+		}
+	}
+	return "", false
+}
+
+// SortedKeys returns all message keys of a catalog in alphabetical order.
+func (catalog messageCatalog) sortedKeys() []messageKey {
+	keys := make([]messageKey, 0, len(catalog))
+	for key := range catalog {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
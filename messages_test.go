@@ -0,0 +1,87 @@
+package timetracker
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MessagesTestSuite struct {
+	suite.Suite
+}
+
+func TestMessagesTestSuite(t *testing.T) {
+	suite.Run(t, new(MessagesTestSuite))
+}
+
+func (suite *MessagesTestSuite) TestLanguageOfLocale() {
+
+	suite.Equal(ENGLISH, languageOf(Locale{Country: "de"}))
+	suite.Equal(ENGLISH, languageOf(Locale{Country: "AT"}))
+	suite.Equal(GERMAN, languageOf(Locale{Country: "de", Language: GERMAN}))
+	suite.Equal(ENGLISH, languageOf(Locale{Country: "de", Language: ENGLISH}))
+	suite.Equal(GERMAN, languageOf(Locale{Country: "us", Language: "DE"}))
+	suite.Equal(ENGLISH, languageOf(Locale{Country: "us"}))
+	suite.Equal(ENGLISH, languageOf(Locale{Country: "fr", Language: "fr"}))
+}
+
+func (suite *MessagesTestSuite) TestMessages() {
+
+	german := messagesFor(Locale{Language: GERMAN})
+	suite.Equal("Arbeitszeit", german.message(msgWorkingTime))
+	suite.Equal("Urlaub", german.message(messageKey(VACATION)))
+	suite.Equal("Sonntag", german.weekday(time.Sunday))
+
+	english := messagesFor(Locale{})
+	suite.Equal("WorkingTime", english.message(msgWorkingTime))
+	suite.Equal("Vacation", english.message(messageKey(VACATION)))
+	suite.Equal("Sunday", english.weekday(time.Sunday))
+
+	suite.Equal("Date", messageCatalog{}.message(msgDate))
+	suite.Equal("unknown", german.message(messageKey("unknown")))
+}
+
+func (suite *MessagesTestSuite) TestCompleteCatalogs() {
+	for language, catalog := range messageCatalogs {
+		for key := range messageCatalogs[ENGLISH] {
+			_, ok := catalog[key]
+			suite.True(ok, "Missing message %s for language %s", key, language)
+		}
+	}
+}
+
+func (suite *MessagesTestSuite) TestUniqueLabels() {
+
+	keys := make(map[string]messageKey)
+	for language, catalog := range messageCatalogs {
+		for key, label := range catalog {
+			if otherKey, ok := keys[strings.ToLower(label)]; ok {
+				suite.Equal(otherKey, key, "Label %s of language %s is used for different keys", label, language)
+			}
+			keys[strings.ToLower(label)] = key
+		}
+	}
+
+	key, ok := messageKeyOf("Mitarbeiter")
+	suite.True(ok)
+	suite.Equal(msgEmployee, key)
+	key, ok = messageKeyOf("teammitglied")
+	suite.True(ok)
+	suite.Equal(msgMember, key)
+	_, ok = messageKeyOf("unknown")
+	suite.False(ok)
+}
+
+func (suite *MessagesTestSuite) TestFormatterLanguage() {
+
+	formatter := NewCSVReportFormatter(loggerForTest())
+	report := monthlyReportForTest()
+	report.Location.Language = ENGLISH
+
+	buf, err := formatter.WriteMonthlyReportToBuffer(report)
+	suite.Nil(err)
+	suite.Contains(buf.String(), "Date,Start,End,WorkingTime,BreakTime,Comment\n")
+	suite.Contains(buf.String(), "10.01.2022,,,08:00,00:30,Illness\n")
+}
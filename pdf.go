@@ -0,0 +1,217 @@
+package timetracker
+
+import (
+	"bytes"
+	"os"
+	"strconv"
+
+	log "github.com/tommzn/go-log"
+)
+
+// PdfColumns are x positions of all table columns.
+var pdfColumns = []float64{40, 120, 170, 220, 300, 370}
+
+const (
+
+	// PdfMargin is the margin at top and bottom of each page.
+	pdfMargin = 40.0
+
+	// PdfRowHeight is the height of a single table row.
+	pdfRowHeight = 16.0
+
+	// PdfFontSize is the font size used for table content.
+	pdfFontSize = 9.0
+)
+
+// NewPDFReportFormatter returns a new formatter to generate PDF documents for reports.
+func NewPDFReportFormatter(logger log.Logger) *PDFReportFormatter {
+	return &PDFReportFormatter{
+		reportFormat: newReportFormat(logger),
+	}
+}
+
+// PDFReportFormatter generates PDF documents with a monthly table, totals, holidays and a signature block.
+type PDFReportFormatter struct {
+
+	// ReportFormat contains date/time formats, timezone and holidays.
+	reportFormat
+
+	// Employee is the name of the employee printed below signature line.
+	employee string
+
+	// Supervisor is the name of the supervisor printed below signature line.
+	supervisor string
+}
+
+// WithSignatures defines names of employee and supervisor, printed in signature block.
+func (formatter *PDFReportFormatter) WithSignatures(employee, supervisor string) {
+	formatter.employee = employee
+	formatter.supervisor = supervisor
+}
+
+// FileExtension returns file extension for PDF files: pdf.
+func (formatter *PDFReportFormatter) FileExtension() string {
+	return ".pdf"
+}
+
+// WriteMonthlyReportToFile will generate a report outout an writes it to given file.
+func (formatter *PDFReportFormatter) WriteMonthlyReportToFile(report *MonthlyReport, filename string) error {
+
+	buf, err := formatter.WriteMonthlyReportToBuffer(report)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, buf.Bytes(), 0644)
+}
+
+// WriteMonthlyReportToBuffer returns a buffer for gemerated report output.
+func (formatter *PDFReportFormatter) WriteMonthlyReportToBuffer(report *MonthlyReport) (*bytes.Buffer, error) {
+
+	formatter.applyLocale(report)
+	doc := newPdfDocument()
+	doc.addPage()
+
+	y := pdfPageHeight - pdfMargin - 20
+	doc.text(pdfColumns[0], y, 16, true, formatter.reportTitle(report))
+
+	y -= 2 * pdfRowHeight
+	formatter.writeTableHeader(doc, y)
+	forEachDayOfMonth(report, func(day Day) {
+		y -= pdfRowHeight
+		if y < pdfMargin {
+			doc.addPage()
+			y = pdfPageHeight - pdfMargin - pdfRowHeight
+			formatter.writeTableHeader(doc, y)
+			y -= pdfRowHeight
+		}
+		formatter.writeRowForDay(doc, y, day)
+	})
+	doc.line(pdfColumns[0], y-4, pdfPageWidth-pdfColumns[0], y-4, 1.5)
+
+	y = formatter.writeTotals(doc, y-pdfRowHeight-4, report)
+	y = formatter.writeHolidays(doc, y-pdfRowHeight, report)
+	formatter.writeSignatureBlock(doc, y-pdfRowHeight)
+	return bytes.NewBuffer(doc.bytes()), nil
+}
+
+// WriteTableHeader writes column names at given position.
+func (formatter *PDFReportFormatter) writeTableHeader(doc *pdfDocument, y float64) {
+	for idx, header := range formatter.tableHeaders() {
+		doc.text(pdfColumns[idx], y, pdfFontSize, true, header)
+	}
+	doc.line(pdfColumns[0], y-4, pdfPageWidth-pdfColumns[0], y-4, 1.5)
+}
+
+// WriteRowForDay writes values of a single day. Background colors are applied in same order as in Excel reports.
+func (formatter *PDFReportFormatter) writeRowForDay(doc *pdfDocument, y float64, day Day) {
+
+	color := ""
+	if recordTypeFormat, ok := recordTypeFormats()[day.Type]; ok {
+		color = recordTypeFormat.color
+	}
+	if isWeekend(day.Date.AsTime()) {
+		color = "#FEC7CE"
+	}
+	if _, ok := formatter.holidays[day.Date]; ok {
+		color = "#FED7DE"
+	}
+	if color != "" {
+		doc.rect(pdfColumns[0], y-4, pdfPageWidth-2*pdfColumns[0], pdfRowHeight, color)
+	}
+
+	values := []string{formatter.formatDate(day.Date), "", "", formatDuration(day.WorkingTime), formatDuration(day.BreakTime), formatter.commentOf(day)}
+	if len(day.Events) > 0 {
+		values[1] = formatter.formatTime(day.Events[0].Timestamp)
+	}
+	if len(day.Events) > 1 {
+		values[2] = formatter.formatTime(day.Events[len(day.Events)-1].Timestamp)
+	}
+	for idx, value := range values {
+		doc.text(pdfColumns[idx], y, pdfFontSize, false, value)
+	}
+}
+
+// WriteTotals writes total working time, target working time, overtime and days per work location. Returns y position of last line.
+func (formatter *PDFReportFormatter) writeTotals(doc *pdfDocument, y float64, report *MonthlyReport) float64 {
+
+	totals := [][]string{{formatter.message(msgTotalWorkingTime), formatDuration(report.TotalWorkingTime)}}
+	if report.TargetWorkingTime > 0 {
+		totals = append(totals, []string{formatter.message(msgTargetWorkingTime), formatDuration(report.TargetWorkingTime)})
+		totals = append(totals, []string{formatter.message(msgOvertime), formatDuration(report.Overtime)})
+	}
+	for _, location := range formatter.workLocationDaysOf(report.WorkLocationDays) {
+		totals = append(totals, []string{location.label, strconv.Itoa(location.days)})
+	}
+	for idx, total := range totals {
+		if idx > 0 {
+			y -= pdfRowHeight
+		}
+		y = formatter.ensurePageSpace(doc, y)
+		doc.text(pdfColumns[0], y, pdfFontSize, true, total[0])
+		doc.text(pdfColumns[3], y, pdfFontSize, true, total[1])
+	}
+	return y
+}
+
+// WriteHolidays writes a list of all holidays of report month. Returns y position of last line.
+func (formatter *PDFReportFormatter) writeHolidays(doc *pdfDocument, y float64, report *MonthlyReport) float64 {
+
+	holidays := []Holiday{}
+	forEachDayOfMonth(report, func(day Day) {
+		if holiday, ok := formatter.holidays[day.Date]; ok {
+			holidays = append(holidays, holiday)
+		}
+	})
+	if len(holidays) == 0 {
+		return y + pdfRowHeight
+	}
+
+	y = formatter.ensurePageSpace(doc, y)
+	doc.text(pdfColumns[0], y, pdfFontSize, true, formatter.message(msgHolidays))
+	for _, holiday := range holidays {
+		y = formatter.ensurePageSpace(doc, y-pdfRowHeight)
+		doc.text(pdfColumns[0], y, pdfFontSize, false, formatter.formatDate(holiday.Date))
+		doc.text(pdfColumns[1], y, pdfFontSize, false, holiday.Description)
+	}
+	return y
+}
+
+// EnsurePageSpace adds a new page if given y position is below bottom margin of current page.
+// Returns y position of first line on a new page, or given position if there's enough space left.
+func (formatter *PDFReportFormatter) ensurePageSpace(doc *pdfDocument, y float64) float64 {
+	if y < pdfMargin {
+		doc.addPage()
+		return pdfPageHeight - pdfMargin - pdfRowHeight
+	}
+	return y
+}
+
+// WriteSignatureBlock writes date and signature lines for employee and supervisor.
+// A new page is added if there's not enough space left on current page.
+func (formatter *PDFReportFormatter) writeSignatureBlock(doc *pdfDocument, y float64) {
+
+	if y-4*pdfRowHeight < pdfMargin {
+		doc.addPage()
+		y = pdfPageHeight - pdfMargin - pdfRowHeight
+	}
+
+	y -= 2 * pdfRowHeight
+	signatures := []struct {
+		x           float64
+		label, name string
+	}{
+		{x: pdfColumns[0], label: formatter.message(msgEmployee), name: formatter.employee},
+		{x: pdfPageWidth / 2, label: formatter.message(msgSupervisor), name: formatter.supervisor},
+	}
+	for _, signature := range signatures {
+		doc.text(signature.x, y+pdfRowHeight, pdfFontSize, true, signature.label)
+		doc.line(signature.x, y, signature.x+60, y, 0.5)
+		doc.line(signature.x+70, y, signature.x+220, y, 0.5)
+		doc.text(signature.x, y-12, pdfFontSize-1, false, formatter.message(msgDate))
+		signatureCaption := formatter.message(msgSignature)
+		if signature.name != "" {
+			signatureCaption += ", " + signature.name
+		}
+		doc.text(signature.x+70, y-12, pdfFontSize-1, false, signatureCaption)
+	}
+}
@@ -0,0 +1,133 @@
+package timetracker
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+
+	// PdfPageWidth is the width of an A4 page in points.
+	pdfPageWidth = 595.0
+
+	// PdfPageHeight is the height of an A4 page in points.
+	pdfPageHeight = 842.0
+)
+
+// NewPdfDocument creates a new, empty PDF document.
+func newPdfDocument() *pdfDocument {
+	return &pdfDocument{pages: []*bytes.Buffer{}}
+}
+
+// PdfDocument is a minimal PDF writer which supports text in standard fonts Helvetica and Helvetica-Bold,
+// lines and filled rectangles on A4 pages. Origin of coordinates is the bottom left corner of a page.
+type pdfDocument struct {
+	pages []*bytes.Buffer
+}
+
+// AddPage appends a new, empty page. All following drawings are written to this page.
+func (doc *pdfDocument) addPage() {
+	doc.pages = append(doc.pages, new(bytes.Buffer))
+}
+
+// Text writes given text at passed position. Characters which can't be encoded with WinAnsiEncoding are replaced by "?".
+func (doc *pdfDocument) text(x, y, size float64, bold bool, text string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(doc.currentPage(), "BT /%s %s Tf %s %s Td (%s) Tj ET\n", font, pdfNumber(size), pdfNumber(x), pdfNumber(y), pdfEscape(text))
+}
+
+// Line draws a black line with given width between two points.
+func (doc *pdfDocument) line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(doc.currentPage(), "0 0 0 RG %s w %s %s m %s %s l S\n", pdfNumber(width), pdfNumber(x1), pdfNumber(y1), pdfNumber(x2), pdfNumber(y2))
+}
+
+// Rect draws a filled rectangle. Color has to be passed in hex format, e.g. #ff6600.
+// Graphics state is saved and restored, so the fill color doesn't apply to following text.
+func (doc *pdfDocument) rect(x, y, width, height float64, color string) {
+	r, g, b := pdfColor(color)
+	fmt.Fprintf(doc.currentPage(), "q %s %s %s rg %s %s %s %s re f Q\n", pdfNumber(r), pdfNumber(g), pdfNumber(b), pdfNumber(x), pdfNumber(y), pdfNumber(width), pdfNumber(height))
+}
+
+// Bytes returns the entire PDF document.
+func (doc *pdfDocument) bytes() []byte {
+
+	buf := new(bytes.Buffer)
+	offsets := []int{}
+	writeObject := func(content string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(buf, "%d 0 obj\n%s\nendobj\n", len(offsets), content)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+	pageIds := []string{}
+	for idx := range doc.pages {
+		pageIds = append(pageIds, fmt.Sprintf("%d 0 R", 5+idx*2))
+	}
+	writeObject("<< /Type /Catalog /Pages 2 0 R >>")
+	writeObject(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(pageIds, " "), len(doc.pages)))
+	writeObject("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	writeObject("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for idx, page := range doc.pages {
+		writeObject(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pdfNumber(pdfPageWidth), pdfNumber(pdfPageHeight), 6+idx*2))
+		writeObject(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", page.Len(), page.String()))
+	}
+
+	xrefOffset := buf.Len()
+	fmt.Fprintf(buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xrefOffset)
+	return buf.Bytes()
+}
+
+// CurrentPage returns content of last page. A new page is created if there's no page, yet.
+func (doc *pdfDocument) currentPage() *bytes.Buffer {
+	if len(doc.pages) == 0 {
+		doc.addPage()
+	}
+	return doc.pages[len(doc.pages)-1]
+}
+
+// PdfNumber formats given number with max two decimal places.
+func pdfNumber(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
+
+// PdfColor converts a color in hex format, e.g. #ff6600, to RGB values between 0 and 1.
+// Returns black for invalid colors.
+func pdfColor(color string) (float64, float64, float64) {
+	value, err := strconv.ParseUint(strings.TrimPrefix(color, "#"), 16, 32)
+	if err != nil || len(strings.TrimPrefix(color, "#")) != 6 {
+		return 0, 0, 0
+	}
+	return float64(value>>16&0xff) / 255, float64(value>>8&0xff) / 255, float64(value&0xff) / 255
+}
+
+// PdfEscape encodes given text with WinAnsiEncoding and escapes all special characters of PDF strings.
+func pdfEscape(text string) string {
+	escaped := new(strings.Builder)
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			escaped.WriteRune('\\')
+			escaped.WriteRune(r)
+		case r >= 0x20 && r < 0x7f:
+			escaped.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(escaped, "\\%03o", r)
+		case r == '€':
+			escaped.WriteString("\\200")
+		default:
+			escaped.WriteRune('?')
+		}
+	}
+	return escaped.String()
+}
@@ -0,0 +1,127 @@
+package timetracker
+
+import (
+	"bytes"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PDFReportFormatterTestSuite struct {
+	suite.Suite
+}
+
+func TestPDFReportFormatterTestSuite(t *testing.T) {
+	suite.Run(t, new(PDFReportFormatterTestSuite))
+}
+
+func (suite *PDFReportFormatterTestSuite) TestGenerateReport() {
+
+	formatter := NewPDFReportFormatter(loggerForTest())
+	formatter.WithHolidays([]Holiday{Holiday{Date: Date{Year: 2022, Month: 1, Day: 6}, Description: "Heilige Drei Könige"}})
+	formatter.WithSignatures("Jane Doe", "John Doe")
+	report := monthlyReportForTest()
+	report.Location.WorkSchedule = workScheduleForTest()
+	report.TargetWorkingTime = 16 * time.Hour
+	report.Overtime = 1 * time.Hour
+
+	buf, err := formatter.WriteMonthlyReportToBuffer(report)
+	suite.Nil(err)
+	content := buf.Bytes()
+	suite.True(bytes.HasPrefix(content, []byte("%PDF-1.4\n")))
+	suite.True(bytes.HasSuffix(content, []byte("%%EOF\n")))
+	suite.Contains(string(content), "(Arbeitszeitnachweis 2022-01) Tj")
+	suite.Contains(string(content), "(Heilige Drei K\\366nige) Tj")
+	suite.Contains(string(content), "(Unterschrift, Jane Doe) Tj")
+	suite.Contains(string(content), "(Unterschrift, John Doe) Tj")
+	suite.Contains(string(content), "(\\334berstunden) Tj")
+	suite.Contains(string(content), "/Count 1 >>")
+	suite.assertXref(content)
+
+	filename := suite.T().TempDir() + "/report" + formatter.FileExtension()
+	suite.Nil(formatter.WriteMonthlyReportToFile(report, filename))
+	_, err = os.Stat(filename)
+	suite.Nil(err)
+}
+
+func (suite *PDFReportFormatterTestSuite) TestFillColorIsReset() {
+
+	formatter := NewPDFReportFormatter(loggerForTest())
+	buf, err := formatter.WriteMonthlyReportToBuffer(monthlyReportForTest())
+	suite.Nil(err)
+	content := buf.String()
+
+	// 1 Jan 2022 is a Saturday, its row has a colored background.
+	suite.Contains(content, "q 1 0.78 0.81 rg")
+	for _, line := range strings.Split(content, "\n") {
+		if strings.Contains(line, " rg ") {
+			suite.True(strings.HasPrefix(line, "q ") && strings.HasSuffix(line, " Q"), line)
+		}
+	}
+}
+
+func (suite *PDFReportFormatterTestSuite) TestPageBreakForTotalsAndHolidays() {
+
+	formatter := NewPDFReportFormatter(loggerForTest())
+	formatter.WithHolidays([]Holiday{
+		Holiday{Date: Date{Year: 2022, Month: 1, Day: 1}, Description: "Neujahr"},
+		Holiday{Date: Date{Year: 2022, Month: 1, Day: 6}, Description: "Heilige Drei Könige"},
+	})
+	report := monthlyReportForTest()
+	report.TargetWorkingTime = 16 * time.Hour
+	formatter.applyLocale(report)
+
+	doc := newPdfDocument()
+	doc.addPage()
+	y := formatter.writeTotals(doc, pdfMargin+pdfRowHeight, report)
+	suite.Len(doc.pages, 2)
+	suite.True(y >= pdfMargin)
+
+	doc = newPdfDocument()
+	doc.addPage()
+	y = formatter.writeHolidays(doc, pdfMargin+pdfRowHeight, report)
+	suite.Len(doc.pages, 2)
+	suite.True(y >= pdfMargin)
+	suite.Contains(doc.pages[1].String(), "(Heilige Drei K\\366nige) Tj")
+}
+
+func (suite *PDFReportFormatterTestSuite) TestWorkLocationDays() {
+
+	report := monthlyReportForTest()
+	report.WorkLocationDays = map[WorkLocation]int{HOME: 3, OFFICE: 1}
+	buf, err := NewPDFReportFormatter(loggerForTest()).WriteMonthlyReportToBuffer(report)
+	suite.Nil(err)
+	suite.Contains(buf.String(), "(Homeoffice \\(Tage\\)) Tj")
+	suite.Contains(buf.String(), "(B\\374ro \\(Tage\\)) Tj")
+}
+
+func (suite *PDFReportFormatterTestSuite) TestPdfHelpers() {
+
+	r, g, b := pdfColor("#ff6600")
+	suite.Equal(1.0, r)
+	suite.Equal(0.4, g)
+	suite.Equal(0.0, b)
+	r, _, _ = pdfColor("invalid")
+	suite.Equal(0.0, r)
+
+	suite.Equal("1.33", pdfNumber(4.0/3))
+	suite.Equal("\\(a\\\\b\\) \\344 ?", pdfEscape("(a\\b) ä 日"))
+}
+
+func (suite *PDFReportFormatterTestSuite) assertXref(content []byte) {
+
+	matches := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(content)
+	suite.NotNil(matches)
+	xrefOffset, _ := strconv.Atoi(string(matches[1]))
+	suite.True(bytes.HasPrefix(content[xrefOffset:], []byte("xref\n")))
+
+	for _, match := range regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(content, -1) {
+		offset, _ := strconv.Atoi(string(match[1]))
+		suite.Regexp(`^\d+ 0 obj\n`, string(content[offset:offset+12]))
+	}
+}
@@ -0,0 +1,127 @@
+package timetracker
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// NewLocalPersonRegistry creates a new, in memory, person registry.
+func NewLocalPersonRegistry() *LocalPersonRegistry {
+	return &LocalPersonRegistry{Persons: make(map[string]Person)}
+}
+
+// NewPersonTimeTracker returns a time tracker which uses person ids instead of device ids
+// and merges time tracking records of all devices of a person.
+func NewPersonTimeTracker(tracker TimeTracker, registry PersonRegistry) *PersonTimeTracker {
+	return &PersonTimeTracker{
+		tracker:         tracker,
+		registry:        registry,
+		duplicateWindow: 1 * time.Minute,
+	}
+}
+
+// LocalPersonRegistry is an in memory registry of persons.
+type LocalPersonRegistry struct {
+	Persons map[string]Person
+}
+
+// Add will store given person. An existing person with same id will be replaced.
+func (registry *LocalPersonRegistry) Add(person Person) {
+	registry.Persons[person.Id] = person
+}
+
+// GetPerson returns a person for given id.
+func (registry *LocalPersonRegistry) GetPerson(id string) (*Person, error) {
+	if person, ok := registry.Persons[id]; ok {
+		return &person, nil
+	}
+	return nil, errors.New("Invalid person id: " + id)
+}
+
+// PersonTimeTracker is a time tracker view for persons with multiple devices.
+type PersonTimeTracker struct {
+
+	// Tracker is used to capture and list time tracking records of a single device.
+	tracker TimeTracker
+
+	// Registry is used to get all devices of a person.
+	registry PersonRegistry
+
+	// DuplicateWindow is the maximum time between two records of same type, captured by
+	// different devices, to handle them as duplicates.
+	duplicateWindow time.Duration
+}
+
+// WithDuplicateWindow sets maximum time between two records of same type from different devices
+// to treat them as duplicates. Default is one minute.
+func (tracker *PersonTimeTracker) WithDuplicateWindow(duplicateWindow time.Duration) {
+	tracker.duplicateWindow = duplicateWindow
+}
+
+// Capture will create a time tracking record for first device of given person.
+func (tracker *PersonTimeTracker) Capture(personId string, recordType RecordType) error {
+	return tracker.Captured(personId, recordType, time.Now())
+}
+
+// Captured creates a time tracking record for passed point in time for first device of given person.
+func (tracker *PersonTimeTracker) Captured(personId string, recordType RecordType, timestamp time.Time) error {
+
+	person, err := tracker.registry.GetPerson(personId)
+	if err != nil {
+		return err
+	}
+	if len(person.DeviceIds) == 0 {
+		return errors.New("No device assigned to person: " + personId)
+	}
+	return tracker.tracker.Captured(person.DeviceIds[0], recordType, timestamp)
+}
+
+// ListRecords returns time tracking records of all devices of given person for passed range.
+// Records are sorted by timestamp and duplicates, captured by different devices, are removed.
+func (tracker *PersonTimeTracker) ListRecords(personId string, start time.Time, end time.Time) ([]TimeTrackingRecord, error) {
+
+	records := []TimeTrackingRecord{}
+	person, err := tracker.registry.GetPerson(personId)
+	if err != nil {
+		return records, err
+	}
+
+	for _, deviceId := range person.DeviceIds {
+		deviceRecords, err := tracker.tracker.ListRecords(deviceId, start, end)
+		if err != nil {
+			return records, err
+		}
+		records = append(records, deviceRecords...)
+	}
+	sort.SliceStable(records, func(i, j int) bool { return records[i].Timestamp.Before(records[j].Timestamp) })
+	return tracker.removeDuplicates(records), nil
+}
+
+// RemoveDuplicates removes records from given, sorted, list which have been captured by another device
+// with same type within duplicate window.
+func (tracker *PersonTimeTracker) removeDuplicates(records []TimeTrackingRecord) []TimeTrackingRecord {
+
+	uniqueRecords := []TimeTrackingRecord{}
+	for _, record := range records {
+		if !tracker.isDuplicate(record, uniqueRecords) {
+			uniqueRecords = append(uniqueRecords, record)
+		}
+	}
+	return uniqueRecords
+}
+
+// IsDuplicate returns true if there's a record of another device in given list with same type
+// and a timestamp within duplicate window.
+func (tracker *PersonTimeTracker) isDuplicate(record TimeTrackingRecord, records []TimeTrackingRecord) bool {
+	for idx := len(records) - 1; idx >= 0; idx-- {
+		timeBetween := record.Timestamp.Sub(records[idx].Timestamp)
+		if timeBetween > tracker.duplicateWindow {
+			return false
+		}
+		if records[idx].Type == record.Type && records[idx].DeviceId != record.DeviceId {
+			return true
+		}
+	}
+	return false
+}
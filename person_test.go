@@ -0,0 +1,63 @@
+package timetracker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PersonTimeTrackerTestSuite struct {
+	suite.Suite
+}
+
+func TestPersonTimeTrackerTestSuite(t *testing.T) {
+	suite.Run(t, new(PersonTimeTrackerTestSuite))
+}
+
+func (suite *PersonTimeTrackerTestSuite) TestCapture() {
+
+	repo := NewLocaLRepository()
+	tracker := NewPersonTimeTracker(repo, personRegistryForTest())
+
+	suite.Nil(tracker.Capture("Person01", WORKDAY))
+	suite.Len(repo.Records["Device01"], 1)
+	suite.Len(repo.Records["Device02"], 0)
+
+	suite.NotNil(tracker.Capture("Person02", WORKDAY))
+	suite.NotNil(tracker.Capture("Person03", WORKDAY))
+}
+
+func (suite *PersonTimeTrackerTestSuite) TestListRecords() {
+
+	repo := NewLocaLRepository()
+	repo.Captured("Device01", WORKDAY, asTime("2022-02-01T08:00:00"))
+	repo.Captured("Device02", WORKDAY, asTime("2022-02-01T08:00:30"))
+	repo.Captured("Device02", WORKDAY, asTime("2022-02-01T12:00:00"))
+	repo.Captured("Device01", WORKDAY, asTime("2022-02-01T13:00:00"))
+	repo.Captured("Device01", WORKDAY, asTime("2022-02-01T16:00:00"))
+	repo.Captured("Device01", WORKDAY, asTime("2022-02-01T16:00:20"))
+	repo.Captured("Device03", WORKDAY, asTime("2022-02-01T09:00:00"))
+	tracker := NewPersonTimeTracker(repo, personRegistryForTest())
+
+	records, err := tracker.ListRecords("Person01", asTime("2022-02-01T00:00:00"), asTime("2022-02-01T23:59:59"))
+	suite.Nil(err)
+	suite.Len(records, 5)
+	suite.Equal(asTime("2022-02-01T08:00:00"), records[0].Timestamp)
+	suite.Equal("Device02", records[1].DeviceId)
+
+	tracker.WithDuplicateWindow(0)
+	records2, err2 := tracker.ListRecords("Person01", asTime("2022-02-01T00:00:00"), asTime("2022-02-01T23:59:59"))
+	suite.Nil(err2)
+	suite.Len(records2, 6)
+
+	_, err3 := tracker.ListRecords("Person03", time.Now(), time.Now())
+	suite.NotNil(err3)
+}
+
+func personRegistryForTest() *LocalPersonRegistry {
+	registry := NewLocalPersonRegistry()
+	registry.Add(Person{Id: "Person01", Name: "Jane Doe", DeviceIds: []string{"Device01", "Device02"}})
+	registry.Add(Person{Id: "Person02", Name: "John Doe", DeviceIds: []string{}})
+	return registry
+}
@@ -8,8 +8,10 @@ import (
 // NewReportCalulator returns a new calulator using given time tracking records and local.
 func NewReportCalulator(records []TimeTrackingRecord, location Locale) *ReportCalulator {
 	return &ReportCalulator{
-		location: location,
-		records:  records,
+		location:   location,
+		records:    records,
+		precedence: defaultTypePrecedence(),
+		holidays:   make(map[Date]Holiday),
 	}
 }
 
@@ -21,6 +23,12 @@ type ReportCalulator struct {
 
 	// Records os a list of all time tracking events a report should be generated for.
 	records []TimeTrackingRecord
+
+	// Precedence is a list of record types, ordered by priority, used to determine type of a day.
+	precedence []RecordType
+
+	// Holidays is a list of public holidays. There's no target working time on holidays.
+	holidays map[Date]Holiday
 }
 
 // WithTimeTrackingRecords will apply given records for calculation.
@@ -28,6 +36,17 @@ func (calculator *ReportCalulator) WithTimeTrackingRecords(records []TimeTrackin
 	calculator.records = records
 }
 
+// WithTypePrecedence will replace default order of record types used to determine type of a day.
+// First type in passed list has highest priority. Record types which are not part of this list will be ignored.
+func (calculator *ReportCalulator) WithTypePrecedence(precedence []RecordType) {
+	calculator.precedence = precedence
+}
+
+// WithHolidays will assign given list of public holidays for calculation of target working time.
+func (calculator *ReportCalulator) WithHolidays(holidays []Holiday) {
+	calculator.holidays = asHolidayMap(holidays)
+}
+
 // MonthlyReport generates a report for given year and month from existing time tracking records.
 func (calculator *ReportCalulator) MonthlyReport(year, month int, latestType RecordType) (*MonthlyReport, error) {
 
@@ -37,6 +56,7 @@ func (calculator *ReportCalulator) MonthlyReport(year, month int, latestType Rec
 		Location:         calculator.location,
 		Days:             []Day{},
 		TotalWorkingTime: time.Duration(0),
+		WorkLocationDays: make(map[WorkLocation]int),
 	}
 
 	days := splitToDays(calculator.records)
@@ -49,10 +69,55 @@ func (calculator *ReportCalulator) MonthlyReport(year, month int, latestType Rec
 			report.Days = append(report.Days, day)
 		}
 	}
-	fillVacationAndIllness(report, latestType)
+	calculator.fillVacationAndIllness(report, latestType)
+	calculator.calculateOvertime(report)
+	countWorkLocationDays(report)
+	return report, nil
+}
+
+// YearlyReport generates monthly reports for all months of given year and summarizes them.
+// Type of last day in a month is passed as latest type to calculation of following month.
+func (calculator *ReportCalulator) YearlyReport(year int, latestType RecordType) (*YearlyReport, error) {
+
+	report := &YearlyReport{
+		Year:             year,
+		Location:         calculator.location,
+		Months:           []MonthlyReport{},
+		WorkLocationDays: make(map[WorkLocation]int),
+	}
+
+	for month := 1; month <= 12; month++ {
+
+		monthlyReport, err := calculator.MonthlyReport(year, month, latestType)
+		if err != nil {
+			return nil, err
+		}
+		if len(monthlyReport.Days) > 0 {
+			latestType = monthlyReport.Days[len(monthlyReport.Days)-1].Type
+		}
+
+		report.TotalWorkingTime += monthlyReport.TotalWorkingTime
+		report.TargetWorkingTime += monthlyReport.TargetWorkingTime
+		report.Overtime += monthlyReport.Overtime
+		for workLocation, days := range monthlyReport.WorkLocationDays {
+			report.WorkLocationDays[workLocation] += days
+		}
+		report.Months = append(report.Months, *monthlyReport)
+	}
 	return report, nil
 }
 
+// DefaultTypePrecedence returns default order of record types to determine type of a day.
+func defaultTypePrecedence() []RecordType {
+	return []RecordType{ILLNESS, PARENTAL_LEAVE, SPECIAL_LEAVE, VACATION, COMP_TIME_OFF, TRAINING, BUSINESS_TRIP, WORKDAY}
+}
+
+// ContinuousTypes returns all types of multi-day absences, e.g. illness or vacation. Days with such a type
+// will be applied to following days until next differing type occurs.
+func continuousTypes() map[RecordType]bool {
+	return map[RecordType]bool{ILLNESS: true, VACATION: true, PARENTAL_LEAVE: true, SPECIAL_LEAVE: true, COMP_TIME_OFF: true}
+}
+
 // GetEndOfWorkingDay will create an estimated time tracking record.
 // If working time from passed records already exceeds given default working time end of working day
 // will be one minute after last available timestamp.
@@ -68,42 +133,75 @@ func getEndOfWorkingDay(records []TimeTrackingRecord, defaultWorkTime time.Durat
 	}
 }
 
-// DetermineTypeOf will analyze given records. In case an ILLNESS or VACATION records is present
-// this type will be returned in all other case default value WORKDAY is returned.
+// DetermineTypeOf will analyze given records and returns the type with highest priority in type precedence.
+// If there's no record with a type from type precedence default value WORKDAY is returned.
 func (calculator *ReportCalulator) determineTypeOf(records []TimeTrackingRecord) RecordType {
 
 	if len(records) == 0 {
 		return WORKDAY
 	}
 
+	dayType := WORKDAY
+	priority := calculator.priorityOf(WORKDAY)
 	for _, record := range records {
-		if record.Type == ILLNESS || record.Type == VACATION {
-			return record.Type
+		if recordPriority := calculator.priorityOf(record.Type); recordPriority < priority {
+			dayType = record.Type
+			priority = recordPriority
 		}
 	}
-	return WORKDAY
+	return dayType
+}
+
+// PriorityOf returns index of given type in type precedence. Lower values mean higher priority.
+// Types not listed in type precedence get lowest priority.
+func (calculator *ReportCalulator) priorityOf(recordType RecordType) int {
+	for idx, precedingType := range calculator.precedence {
+		if precedingType == recordType {
+			return idx
+		}
+	}
+	return len(calculator.precedence)
+}
+
+// IsContinuous returns true for types of multi-day absences listed in type precedence. Days with such types,
+// e.g. illness or vacation, will be continued until next differing type occurs. Business trips and trainings
+// are not continued, they're captured for each day.
+func (calculator *ReportCalulator) isContinuous(recordType RecordType) bool {
+	return continuousTypes()[recordType] && calculator.priorityOf(recordType) < len(calculator.precedence)
 }
 
 // CalculateWorkTimeForDay summarizes total working time of goven day.
+// Only events of working types, WORKDAY, BUSINESS_TRIP and TRAINING, are taken into account.
 // In case an odd number of time tracking records is given it will add an extimated end of the day at first.
 // Then it calculates duration between start and end pair of time tracking records and sum them up to
 // a total working time for the day.
 func (calculator *ReportCalulator) calculateWorkTimeForDay(day *Day) {
 
-	if len(day.Events) == 0 {
+	events := workingEvents(day.Events)
+	if len(events) == 0 {
 		day.WorkingTime = 0
 		day.BreakTime = 0
 		return
 	}
 
 	sort.Slice(day.Events, func(i, j int) bool { return day.Events[i].Timestamp.Before(day.Events[j].Timestamp) })
-	if len(day.Events)%2 != 0 {
-		day.Events = append(day.Events, getEndOfWorkingDay(day.Events, calculator.location.DefaultWorkTime))
+	sort.Slice(events, func(i, j int) bool { return events[i].Timestamp.Before(events[j].Timestamp) })
+	if len(events)%2 != 0 {
+		endOfWorkingDay := getEndOfWorkingDay(events, calculator.location.DefaultWorkTime)
+		events = append(events, endOfWorkingDay)
+		day.Events = append(day.Events, endOfWorkingDay)
 	}
 
-	events := splitTimeTrackingRecords(day.Events, 2)
-	for _, chunkOfEvents := range events {
-		day.WorkingTime += chunkOfEvents[1].Timestamp.Sub(chunkOfEvents[0].Timestamp)
+	workingTimePerLocation := make(map[WorkLocation]time.Duration)
+	for _, chunkOfEvents := range splitTimeTrackingRecords(events, 2) {
+		workingTime := chunkOfEvents[1].Timestamp.Sub(chunkOfEvents[0].Timestamp)
+		day.WorkingTime += workingTime
+		if workLocation := chunkOfEvents[0].WorkLocation; workLocation != "" {
+			workingTimePerLocation[workLocation] += workingTime
+			if workingTimePerLocation[workLocation] > workingTimePerLocation[day.WorkLocation] {
+				day.WorkLocation = workLocation
+			}
+		}
 	}
 }
 
@@ -111,6 +209,11 @@ func (calculator *ReportCalulator) calculateWorkTimeForDay(day *Day) {
 // or existing breaks doesn't reach default settings.
 func (calculator *ReportCalulator) subtractBreaks(day *Day) {
 
+	events := workingEvents(day.Events)
+	if len(events) == 0 {
+		return
+	}
+
 	definedBreakTime := time.Duration(0)
 	for workingTime, timeOfBreak := range calculator.location.Breaks {
 		if day.WorkingTime >= workingTime {
@@ -118,13 +221,73 @@ func (calculator *ReportCalulator) subtractBreaks(day *Day) {
 		}
 	}
 
-	day.BreakTime = day.Events[len(day.Events)-1].Timestamp.Sub(day.Events[0].Timestamp) - day.WorkingTime
+	day.BreakTime = events[len(events)-1].Timestamp.Sub(events[0].Timestamp) - day.WorkingTime
 	if day.BreakTime < definedBreakTime {
 		day.WorkingTime -= definedBreakTime - day.BreakTime
 		day.BreakTime = definedBreakTime
 	}
 }
 
+// CalculateOvertime assigns target working time to each day of given report and summarizes
+// target working time and overtime for the entire month.
+func (calculator *ReportCalulator) calculateOvertime(report *MonthlyReport) {
+
+	report.TargetWorkingTime = time.Duration(0)
+	for idx, day := range report.Days {
+		report.Days[idx].TargetTime = calculator.targetTimeFor(day)
+		report.TargetWorkingTime += report.Days[idx].TargetTime
+	}
+	if len(calculator.location.WorkSchedule) > 0 {
+		report.Overtime = report.TotalWorkingTime - report.TargetWorkingTime
+	}
+}
+
+// TargetTimeFor returns expected working time for given day, defined by work schedule of current locale.
+// There's no target working time on holidays and for days of illness, vacation, special or parental leave.
+// Business trips and trainings will be credited with target working time if no working time has been tracked.
+// Compensatory time off requires target working time without working, so it consumes overtime.
+func (calculator *ReportCalulator) targetTimeFor(day Day) time.Duration {
+
+	if _, ok := calculator.holidays[day.Date]; ok {
+		return time.Duration(0)
+	}
+
+	switch day.Type {
+	case WORKDAY, COMP_TIME_OFF:
+		return calculator.location.WorkSchedule[day.Date.AsTime().Weekday()]
+	case BUSINESS_TRIP, TRAINING:
+		if day.WorkingTime > 0 {
+			return calculator.location.WorkSchedule[day.Date.AsTime().Weekday()]
+		}
+	}
+	return time.Duration(0)
+}
+
+// CountWorkLocationDays summarizes number of days for each work location in given report.
+func countWorkLocationDays(report *MonthlyReport) {
+	for _, day := range report.Days {
+		if day.WorkLocation != "" {
+			report.WorkLocationDays[day.WorkLocation]++
+		}
+	}
+}
+
+// WorkingEvents returns all events of working types, WORKDAY, BUSINESS_TRIP and TRAINING, from given list.
+func workingEvents(records []TimeTrackingRecord) []TimeTrackingRecord {
+	events := []TimeTrackingRecord{}
+	for _, record := range records {
+		if isWorkingType(record.Type) {
+			events = append(events, record)
+		}
+	}
+	return events
+}
+
+// IsWorkingType returns true for record types which capture start or end of working time.
+func isWorkingType(recordType RecordType) bool {
+	return recordType == WORKDAY || recordType == BUSINESS_TRIP || recordType == TRAINING
+}
+
 // SplitToDays will walk trough given time tracking records and assign them to day of a month.
 func splitToDays(records []TimeTrackingRecord) []Day {
 
@@ -160,10 +323,10 @@ func splitTimeTrackingRecords(records []TimeTrackingRecord, chunkSize int) (chun
 	return append(chunks, records)
 }
 
-// FillVacationAndIllness will add vacation and illness days.
-// Thhis applies if a day with type ILLNESS or VACATION is available and following days doesn't exist in the list of days.
+// FillVacationAndIllness will add vacation, illness and all other days with a continuous type, see continuous types.
+// Thhis applies if a day with a continuous type is available and following days doesn't exist in the list of days.
 // For such cases days with same type will be generated until next day in the list or until the end of the month.
-func fillVacationAndIllness(report *MonthlyReport, latestType RecordType) {
+func (calculator *ReportCalulator) fillVacationAndIllness(report *MonthlyReport, latestType RecordType) {
 
 	sort.Slice(report.Days, func(i, j int) bool { return report.Days[i].Date.Before(report.Days[j].Date) })
 	dayOfMonth := time.Date(report.Year, time.Month(report.Month), 1, 0, 0, 0, 0, time.UTC)
@@ -171,18 +334,16 @@ func fillVacationAndIllness(report *MonthlyReport, latestType RecordType) {
 	days := []Day{}
 	for _, day := range report.Days {
 
-		if !day.Date.IsEqual(dayOfMonth) &&
-			(latestType == ILLNESS || latestType == VACATION) {
+		if !day.Date.IsEqual(dayOfMonth) && calculator.isContinuous(latestType) {
 			daysToFill := generateDays(dayOfMonth, day.Date, latestType)
 			days = append(days, daysToFill...)
-		} else {
-			latestType = day.Type
 		}
+		latestType = day.Type
 		dayOfMonth = time.Date(dayOfMonth.Year(), dayOfMonth.Month(), day.Date.Day, 0, 0, 0, 0, time.UTC)
 		days = append(days, day)
 		dayOfMonth = dayOfMonth.AddDate(0, 0, 1)
 	}
-	report.Days = fillToEndOfMonth(days)
+	report.Days = calculator.fillToEndOfMonth(days)
 }
 
 // GenerateDays will create a list of empty days in given range and assign passed type to all of them.
@@ -195,8 +356,8 @@ func generateDays(startDay time.Time, endDay Date, recordType RecordType) []Day
 	return days
 }
 
-// FillToEndOfMonth loop from last day in given list until end of month and fill vacation or illness days if required.
-func fillToEndOfMonth(days []Day) []Day {
+// FillToEndOfMonth loop from last day in given list until end of month and fill days with a continuous type if required.
+func (calculator *ReportCalulator) fillToEndOfMonth(days []Day) []Day {
 
 	if len(days) == 0 {
 		return days
@@ -204,8 +365,7 @@ func fillToEndOfMonth(days []Day) []Day {
 
 	lastDayInList := days[len(days)-1]
 	lastDayOfMonth := lastDayOfMonth(lastDayInList.Date)
-	if (lastDayInList.Type != ILLNESS && lastDayInList.Type != VACATION) ||
-		lastDayInList.Equal(lastDayOfMonth) {
+	if !calculator.isContinuous(lastDayInList.Type) || lastDayInList.Equal(lastDayOfMonth) {
 		return days
 	}
 	daysToFill := generateDays(lastDayInList.Date.AsTime(), lastDayOfMonth, lastDayInList.Type)
@@ -161,6 +161,128 @@ func (suite *ReportCalulatorTestSuite) TestDetermineTypeOfDay() {
 	suite.Equal(WORKDAY, calculator.determineTypeOf(emtpyListOfRecords))
 }
 
+func (suite *ReportCalulatorTestSuite) TestTypePrecedence() {
+
+	records := []TimeTrackingRecord{
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-01T08:00:00")},
+		TimeTrackingRecord{Type: BUSINESS_TRIP, Timestamp: asTime("2022-02-01T09:00:00")},
+		TimeTrackingRecord{Type: VACATION, Timestamp: asTime("2022-02-01T10:00:00")},
+		TimeTrackingRecord{Type: ILLNESS, Timestamp: asTime("2022-02-01T11:00:00")},
+	}
+	calculator := NewReportCalulator(records, localeForTest())
+	suite.Equal(ILLNESS, calculator.determineTypeOf(records))
+	suite.Equal(BUSINESS_TRIP, calculator.determineTypeOf(records[:2]))
+
+	calculator.WithTypePrecedence([]RecordType{BUSINESS_TRIP, VACATION, WORKDAY})
+	suite.Equal(BUSINESS_TRIP, calculator.determineTypeOf(records))
+	suite.True(calculator.isContinuous(VACATION))
+	suite.False(calculator.isContinuous(ILLNESS))
+	suite.False(calculator.isContinuous(BUSINESS_TRIP))
+	suite.False(calculator.isContinuous(WORKDAY))
+}
+
+func (suite *ReportCalulatorTestSuite) TestBusinessTripIsNotContinued() {
+
+	records := []TimeTrackingRecord{
+		TimeTrackingRecord{Type: BUSINESS_TRIP, Timestamp: asTime("2022-02-21T07:00:00")},
+		TimeTrackingRecord{Type: BUSINESS_TRIP, Timestamp: asTime("2022-02-21T17:00:00")},
+		TimeTrackingRecord{Type: PARENTAL_LEAVE, Timestamp: asTime("2022-02-25T08:00:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-28T08:00:00")},
+	}
+	calculator := NewReportCalulator(records, localeForTest())
+	report, err := calculator.MonthlyReport(2022, 2, WORKDAY)
+	suite.Nil(err)
+	suite.Len(report.Days, 5)
+	suite.Equal(21, report.Days[0].Day)
+	suite.Equal(25, report.Days[1].Day)
+	for _, day := range report.Days {
+		switch true {
+		case day.Day < 25:
+			suite.Equal(BUSINESS_TRIP, day.Type)
+		case day.Day < 28:
+			suite.Equal(PARENTAL_LEAVE, day.Type)
+			suite.Equal(time.Duration(0), day.WorkingTime)
+		default:
+			suite.Equal(WORKDAY, day.Type)
+		}
+	}
+}
+
+func (suite *ReportCalulatorTestSuite) TestCalculateOvertime() {
+
+	records := []TimeTrackingRecord{
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-01T08:00:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-01T18:00:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-02T08:00:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-02T16:00:00")},
+		TimeTrackingRecord{Type: COMP_TIME_OFF, Timestamp: asTime("2022-02-03T08:00:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-04T08:00:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-04T16:00:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-05T08:00:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-05T10:00:00")},
+	}
+	locale := localeForTest()
+	locale.WorkSchedule = workScheduleForTest()
+	calculator := NewReportCalulator(records, locale)
+	calculator.WithHolidays([]Holiday{Holiday{Date: Date{Year: 2022, Month: 2, Day: 4}, Description: "Test Holiday"}})
+	report, err := calculator.MonthlyReport(2022, 2, WORKDAY)
+	suite.Nil(err)
+	suite.Len(report.Days, 5)
+	suite.Equal(COMP_TIME_OFF, report.Days[2].Type)
+	suite.Equal(8*time.Hour, report.Days[2].TargetTime)
+	suite.Equal(time.Duration(0), report.Days[3].TargetTime)
+	suite.Equal(time.Duration(0), report.Days[4].TargetTime)
+	suite.Equal(24*time.Hour, report.TargetWorkingTime)
+	suite.Equal(26*time.Hour+15*time.Minute, report.TotalWorkingTime)
+	suite.Equal(2*time.Hour+15*time.Minute, report.Overtime)
+}
+
+func (suite *ReportCalulatorTestSuite) TestWorkLocationDays() {
+
+	records := []TimeTrackingRecord{
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-01T08:00:00"), WorkLocation: HOME},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-01T16:00:00"), WorkLocation: HOME},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-02T08:00:00"), WorkLocation: HOME},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-02T10:00:00"), WorkLocation: HOME},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-02T11:00:00"), WorkLocation: OFFICE},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-02T17:00:00"), WorkLocation: OFFICE},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-03T08:00:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-03T16:00:00")},
+	}
+	calculator := NewReportCalulator(records, localeForTest())
+	report, err := calculator.MonthlyReport(2022, 2, WORKDAY)
+	suite.Nil(err)
+	suite.Len(report.Days, 3)
+	suite.Equal(HOME, report.Days[0].WorkLocation)
+	suite.Equal(OFFICE, report.Days[1].WorkLocation)
+	suite.Equal(WorkLocation(""), report.Days[2].WorkLocation)
+	suite.Equal(map[WorkLocation]int{HOME: 1, OFFICE: 1}, report.WorkLocationDays)
+}
+
+func (suite *ReportCalulatorTestSuite) TestYearlyReport() {
+
+	records := []TimeTrackingRecord{
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-01-31T08:00:00"), WorkLocation: HOME},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-01-31T16:00:00"), WorkLocation: HOME},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-01T08:00:00"), WorkLocation: HOME},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-02-01T16:00:00"), WorkLocation: HOME},
+		TimeTrackingRecord{Type: VACATION, Timestamp: asTime("2022-02-28T08:00:00")},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-03-03T08:00:00"), WorkLocation: CLIENT_SITE},
+		TimeTrackingRecord{Type: WORKDAY, Timestamp: asTime("2022-03-03T16:00:00"), WorkLocation: CLIENT_SITE},
+	}
+	locale := localeForTest()
+	locale.WorkSchedule = workScheduleForTest()
+	calculator := NewReportCalulator(records, locale)
+	report, err := calculator.YearlyReport(2022, WORKDAY)
+	suite.Nil(err)
+	suite.Len(report.Months, 12)
+	suite.Equal(22*time.Hour+30*time.Minute, report.TotalWorkingTime)
+	suite.Equal(24*time.Hour, report.TargetWorkingTime)
+	suite.Equal(-90*time.Minute, report.Overtime)
+	suite.Equal(map[WorkLocation]int{HOME: 2, CLIENT_SITE: 1}, report.WorkLocationDays)
+	suite.Equal(VACATION, report.Months[2].Days[0].Type)
+}
+
 func (suite *ReportCalulatorTestSuite) TestCalulateWorkTime() {
 
 	emtpyListOfRecords := []TimeTrackingRecord{}
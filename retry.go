@@ -0,0 +1,90 @@
+package timetracker
+
+import (
+	"errors"
+	"time"
+)
+
+// RetryPolicy defines how often and with which delay a failed operation is retried.
+// Delays grow exponentially, starting with initial backoff, until max backoff has been reached.
+type RetryPolicy struct {
+
+	// MaxAttempts is the max number of attempts, including the first one. No retries for values less than 2.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff is the max delay between two attempts.
+	MaxBackoff time.Duration
+
+	// Multiplier is applied to a delay after each retry, default is 2.
+	Multiplier float64
+}
+
+// DefaultRetryPolicy returns a policy with 3 attempts and a delay of 1s before first and 2s before second retry.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 1 * time.Second,
+		MaxBackoff:     30 * time.Second,
+		Multiplier:     2,
+	}
+}
+
+// NoRetries returns a policy with a single attempt.
+func NoRetries() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 1}
+}
+
+// PermanentError is an error which should not be retried, e.g. an invalid request.
+type permanentError struct {
+	err error
+}
+
+// Error returns message of the wrapped error.
+func (err *permanentError) Error() string {
+	return err.err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (err *permanentError) Unwrap() error {
+	return err.err
+}
+
+// Permanent marks given error as permanent, so it's not retried.
+func permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// Do calls passed function until it succeeds, it returns a permanent error or max attempts have been reached.
+// Error of last attempt is returned.
+func (policy RetryPolicy) do(fn func() error) error {
+
+	var err error
+	backoff := policy.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+		var permanentErr *permanentError
+		if errors.As(err, &permanentErr) || attempt >= policy.MaxAttempts {
+			return err
+		}
+		time.Sleep(backoff)
+		backoff = policy.nextBackoff(backoff)
+	}
+}
+
+// NextBackoff returns the delay before next retry.
+func (policy RetryPolicy) nextBackoff(backoff time.Duration) time.Duration {
+	multiplier := policy.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+	backoff = time.Duration(float64(backoff) * multiplier)
+	if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+		return policy.MaxBackoff
+	}
+	return backoff
+}
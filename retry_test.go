@@ -0,0 +1,65 @@
+package timetracker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RetryPolicyTestSuite struct {
+	suite.Suite
+}
+
+func TestRetryPolicyTestSuite(t *testing.T) {
+	suite.Run(t, new(RetryPolicyTestSuite))
+}
+
+func (suite *RetryPolicyTestSuite) TestRetries() {
+
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	attempts := 0
+	suite.Nil(policy.do(func() error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("Failed")
+		}
+		return nil
+	}))
+	suite.Equal(3, attempts)
+
+	attempts = 0
+	err := policy.do(func() error {
+		attempts++
+		return errors.New("Failed")
+	})
+	suite.NotNil(err)
+	suite.Equal(3, attempts)
+
+	attempts = 0
+	err = policy.do(func() error {
+		attempts++
+		return permanent(errors.New("Invalid"))
+	})
+	suite.Equal("Invalid", err.Error())
+	suite.Equal(1, attempts)
+
+	attempts = 0
+	suite.NotNil(NoRetries().do(func() error {
+		attempts++
+		return errors.New("Failed")
+	}))
+	suite.Equal(1, attempts)
+}
+
+func (suite *RetryPolicyTestSuite) TestBackoff() {
+
+	policy := DefaultRetryPolicy()
+	suite.Equal(2*time.Second, policy.nextBackoff(policy.InitialBackoff))
+	suite.Equal(30*time.Second, policy.nextBackoff(20*time.Second))
+
+	policy = RetryPolicy{InitialBackoff: time.Second, Multiplier: 1.5}
+	suite.Equal(1500*time.Millisecond, policy.nextBackoff(policy.InitialBackoff))
+	suite.Equal(time.Hour, policy.nextBackoff(40*time.Minute))
+}
@@ -44,15 +44,24 @@ type S3Publisher struct {
 	s3       *s3.S3
 	uploader *s3manager.Uploader
 	logger   log.Logger
+
+	// LinkExpiry defines how long presigned report links are valid. Links are not presigned if it's zero.
+	linkExpiry time.Duration
 }
 
 // S3Repository uses AWS S3 bucket to persist time tracking records.
 type S3Repository struct {
-	bucket     *string
-	basePath   *string
-	s3         *s3.S3
-	downloader *s3manager.Downloader
-	uploader   *s3manager.Uploader
+	bucket        *string
+	basePath      *string
+	s3            *s3.S3
+	downloader    *s3manager.Downloader
+	uploader      *s3manager.Uploader
+	workLocations WorkLocationProvider
+}
+
+// WithWorkLocations applies given provider to assign default work locations of devices to new WORKDAY records.
+func (repo *S3Repository) WithWorkLocations(provider WorkLocationProvider) {
+	repo.workLocations = provider
 }
 
 // Capture will create a time tracking record with passed type at time this method has been called.
@@ -68,6 +77,7 @@ func (repo *S3Repository) Captured(deviceId string, recordType RecordType, times
 		Type:      recordType,
 		Timestamp: timestamp.UTC().Round(time.Second),
 	}
+	timeTrackingRecord = withDefaultWorkLocation(timeTrackingRecord, repo.workLocations)
 	objectPath := repo.newS3ObjectPath(deviceId, timeTrackingRecord.Timestamp)
 	timeTrackingRecord.Key = *objectPath + repo.newRecordId()
 	content, _ := json.Marshal(timeTrackingRecord)
@@ -142,6 +152,7 @@ func (repo *S3Repository) ListRecords(deviceId string, start time.Time, end time
 func (repo *S3Repository) Add(record TimeTrackingRecord) (TimeTrackingRecord, error) {
 
 	record.Timestamp = record.Timestamp.UTC().Round(time.Second)
+	record = withDefaultWorkLocation(record, repo.workLocations)
 	objectPath := repo.newS3ObjectPath(record.DeviceId, record.Timestamp)
 	record.Key = *objectPath + repo.newRecordId()
 
@@ -183,19 +194,63 @@ func (repo *S3Repository) newRecordId() string {
 	return utils.NewId()
 }
 
+// WithPresignedLinks creates report links which are presigned and valid for given duration,
+// so reports can be downloaded without public access to a bucket.
+func (publisher *S3Publisher) WithPresignedLinks(expiry time.Duration) {
+	publisher.linkExpiry = expiry
+}
+
 // Send will upload given report data to AWS S3.
 func (publisher *S3Publisher) Send(data []byte, name string) error {
+	_, err := publisher.Upload(data, name)
+	return err
+}
+
+// Upload stores given report data in AWS S3 and returns the location of the created object.
+func (publisher *S3Publisher) Upload(data []byte, name string) (string, error) {
 	uploadInput := &s3manager.UploadInput{
 		Bucket: publisher.bucket,
 		Key:    publisher.objectKey(name),
 		Body:   bytes.NewReader(data),
 	}
-	if _, uploadErr := publisher.uploader.Upload(uploadInput); uploadErr != nil {
+	uploadOutput, uploadErr := publisher.uploader.Upload(uploadInput)
+	if uploadErr != nil {
 		publisher.logger.Error("Unable to upload file to S3, reason: ", uploadErr)
-		return uploadErr
+		return "", uploadErr
 	}
 	publisher.logger.Debugf("Successfully uploaded to S3 at %s/%s", *uploadInput.Bucket, *uploadInput.Key)
-	return nil
+	return uploadOutput.Location, nil
+}
+
+// ReportLink returns a link to a report uploaded with given name. It's a presigned URL if
+// presigned links are enabled, otherwise the object location, which requires a bucket policy allowing access.
+func (publisher *S3Publisher) ReportLink(name string) (string, error) {
+	if publisher.linkExpiry > 0 {
+		return publisher.PresignedURL(name, publisher.linkExpiry)
+	}
+	return publisher.ObjectLocation(name)
+}
+
+// ObjectLocation returns the URL of a report uploaded with given name.
+func (publisher *S3Publisher) ObjectLocation(name string) (string, error) {
+	request, _ := publisher.s3.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: publisher.bucket,
+		Key:    publisher.objectKey(name),
+	})
+	if err := request.Build(); err != nil {
+		return "", err
+	}
+	return request.HTTPRequest.URL.String(), nil
+}
+
+// PresignedURL returns a time-limited URL to download a report uploaded with given name.
+// URL is signed with current AWS credentials, expiry can't exceed 7 days.
+func (publisher *S3Publisher) PresignedURL(name string, expiry time.Duration) (string, error) {
+	request, _ := publisher.s3.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: publisher.bucket,
+		Key:    publisher.objectKey(name),
+	})
+	return request.Presign(expiry)
 }
 
 // ObjectKey creates a S3 object key for given report name,
@@ -0,0 +1,253 @@
+package timetracker
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// SMTPSecurity defines how a connection to a SMTP server is encrypted.
+type SMTPSecurity string
+
+const (
+
+	// SMTP_STARTTLS upgrades a plain connection to TLS, usually used with port 587.
+	SMTP_STARTTLS SMTPSecurity = "starttls"
+
+	// SMTP_TLS uses implicit TLS, usually with port 465.
+	SMTP_TLS SMTPSecurity = "tls"
+
+	// SMTP_NONE uses an unencrypted connection, e.g. for a local relay.
+	SMTP_NONE SMTPSecurity = "none"
+)
+
+// SMTPAuthMechanism is a mechanism used to authenticate at a SMTP server.
+type SMTPAuthMechanism string
+
+const (
+
+	// SMTP_AUTH_PLAIN sends username and password in a single message, see RFC 4616.
+	SMTP_AUTH_PLAIN SMTPAuthMechanism = "PLAIN"
+
+	// SMTP_AUTH_LOGIN sends username and password on request of a server.
+	SMTP_AUTH_LOGIN SMTPAuthMechanism = "LOGIN"
+)
+
+// NewSMTPPublisher creates a new publisher to send time tracking reports via email using given SMTP server.
+// Default is a connection upgraded with STARTTLS and no authentication.
+func NewSMTPPublisher(host string, port int, source, destination, subject, message string) *SMTPPublisher {
+	return &SMTPPublisher{
+		Source:      source,
+		Destination: destination,
+		Subject:     subject,
+		Message:     message,
+		host:        host,
+		port:        port,
+		security:    SMTP_STARTTLS,
+		timeout:     30 * time.Second,
+	}
+}
+
+// SMTPPublisher delivers time tracking reports via email using a SMTP server, e.g. for self-hosted setups without AWS SES.
+type SMTPPublisher struct {
+	Source, Destination, Subject, Message string
+
+	// EMailOptions contains additional recipients and a plain text message.
+	eMailOptions
+
+	// Host and port of a SMTP server.
+	host string
+	port int
+
+	// Security defines how a connection is encrypted.
+	security SMTPSecurity
+
+	// TlsConfig is used for STARTTLS and implicit TLS. Default config verifies certificates for used host.
+	tlsConfig *tls.Config
+
+	// AuthMechanism, username and password are used to authenticate. No authentication if mechanism is empty.
+	authMechanism      SMTPAuthMechanism
+	username, password string
+
+	// Timeout to connect to a SMTP server.
+	timeout time.Duration
+}
+
+// WithSecurity defines how a connection to a SMTP server is encrypted.
+func (publisher *SMTPPublisher) WithSecurity(security SMTPSecurity) {
+	publisher.security = security
+}
+
+// WithTLSConfig sets a TLS config used for STARTTLS and implicit TLS, e.g. to trust a custom CA.
+func (publisher *SMTPPublisher) WithTLSConfig(tlsConfig *tls.Config) {
+	publisher.tlsConfig = tlsConfig
+}
+
+// WithAuth enables authentication with given mechanism and credentials.
+func (publisher *SMTPPublisher) WithAuth(mechanism SMTPAuthMechanism, username, password string) {
+	publisher.authMechanism = mechanism
+	publisher.username = username
+	publisher.password = password
+}
+
+// WithTimeout sets the timeout to connect to a SMTP server.
+func (publisher *SMTPPublisher) WithTimeout(timeout time.Duration) {
+	publisher.timeout = timeout
+}
+
+// Send will deliver given time tracking report via email. Templates are rendered with the report assigned by WithMonthlyReport.
+func (publisher *SMTPPublisher) Send(content []byte, fileName string) error {
+	return publisher.SendMonthlyReport(content, fileName, publisher.report)
+}
+
+// SendMonthlyReport will deliver given time tracking report via email and renders templates with given monthly report.
+// A passed report is not stored, so a publisher can be used by concurrent sends of different reports.
+func (publisher *SMTPPublisher) SendMonthlyReport(content []byte, fileName string, report *MonthlyReport) error {
+
+	reportLink, err := publisher.reportLinkOf(content, fileName)
+	if err != nil {
+		return err
+	}
+	eMail, err := publisher.newEMail(publisher.Source, publisher.Destination, publisher.Subject, publisher.Message, reportLink, report)
+	if err != nil {
+		return err
+	}
+	rawEMail, err := rawEMail(eMail, content, fileName)
+	if err != nil {
+		return err
+	}
+
+	client, err := publisher.connect()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if auth, err := publisher.auth(); err != nil {
+		return err
+	} else if auth != nil {
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(eMail.from.Address); err != nil {
+		return err
+	}
+	for _, recipient := range eMail.recipients() {
+		if err := client.Rcpt(recipient); err != nil {
+			return err
+		}
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write([]byte(*rawEMail)); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// Connect opens a connection to a SMTP server, encrypted depending on defined security.
+func (publisher *SMTPPublisher) connect() (*smtp.Client, error) {
+
+	address := net.JoinHostPort(publisher.host, strconv.Itoa(publisher.port))
+	dialer := &net.Dialer{Timeout: publisher.timeout}
+	switch publisher.security {
+	case SMTP_TLS:
+		conn, err := tls.DialWithDialer(dialer, "tcp", address, publisher.tlsConfigOf())
+		if err != nil {
+			return nil, err
+		}
+		return smtp.NewClient(conn, publisher.host)
+	case SMTP_STARTTLS, SMTP_NONE:
+		conn, err := dialer.Dial("tcp", address)
+		if err != nil {
+			return nil, err
+		}
+		client, err := smtp.NewClient(conn, publisher.host)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		if publisher.security == SMTP_STARTTLS {
+			if ok, _ := client.Extension("STARTTLS"); !ok {
+				client.Close()
+				return nil, errors.New("SMTP server doesn't support STARTTLS")
+			}
+			if err := client.StartTLS(publisher.tlsConfigOf()); err != nil {
+				client.Close()
+				return nil, err
+			}
+		}
+		return client, nil
+	default:
+		return nil, fmt.Errorf("Invalid SMTP security: %s", publisher.security)
+	}
+}
+
+// TlsConfigOf returns defined TLS config or a default config for current host.
+func (publisher *SMTPPublisher) tlsConfigOf() *tls.Config {
+	if publisher.tlsConfig != nil {
+		return publisher.tlsConfig
+	}
+	return &tls.Config{ServerName: publisher.host}
+}
+
+// Auth returns authentication for defined mechanism, nil if authentication is disabled.
+func (publisher *SMTPPublisher) auth() (smtp.Auth, error) {
+	switch publisher.authMechanism {
+	case "":
+		return nil, nil
+	case SMTP_AUTH_PLAIN:
+		return smtp.PlainAuth("", publisher.username, publisher.password, publisher.host), nil
+	case SMTP_AUTH_LOGIN:
+		return &loginAuth{username: publisher.username, password: publisher.password, host: publisher.host}, nil
+	default:
+		return nil, fmt.Errorf("Invalid SMTP auth mechanism: %s", publisher.authMechanism)
+	}
+}
+
+// LoginAuth implements the LOGIN authentication mechanism, which isn't available in net/smtp.
+type loginAuth struct {
+	username, password, host string
+}
+
+// Start begins an authentication. Same as for PLAIN authentication, credentials are sent
+// over encrypted connections or to localhost only.
+func (auth *loginAuth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	if !server.TLS && !isLocalhost(server.Name) {
+		return "", nil, errors.New("Unencrypted connection")
+	}
+	if server.Name != auth.host {
+		return "", nil, errors.New("Wrong host name")
+	}
+	return string(SMTP_AUTH_LOGIN), nil, nil
+}
+
+// Next answers username and password challenges of a server.
+func (auth *loginAuth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if !more {
+		return nil, nil
+	}
+	switch string(fromServer) {
+	case "Username:", "User Name", "Username":
+		return []byte(auth.username), nil
+	case "Password:", "Password":
+		return []byte(auth.password), nil
+	default:
+		return nil, fmt.Errorf("Unexpected server challenge: %s", fromServer)
+	}
+}
+
+// IsLocalhost returns true for host names of a local machine.
+func isLocalhost(name string) bool {
+	return name == "localhost" || name == "127.0.0.1" || name == "::1"
+}
@@ -0,0 +1,307 @@
+package timetracker
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"math/big"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SMTPPublisherTestSuite struct {
+	suite.Suite
+	serverTLSConfig *tls.Config
+	clientTLSConfig *tls.Config
+}
+
+func TestSMTPPublisherTestSuite(t *testing.T) {
+	suite.Run(t, new(SMTPPublisherTestSuite))
+}
+
+func (suite *SMTPPublisherTestSuite) SetupSuite() {
+	suite.serverTLSConfig, suite.clientTLSConfig = tlsConfigsForTest(suite.T())
+}
+
+func (suite *SMTPPublisherTestSuite) TestSendWithStartTLS() {
+
+	server := newFakeSMTPServer(suite.T(), suite.serverTLSConfig, false)
+	publisher := suite.publisherForTest(server)
+	publisher.WithTLSConfig(suite.clientTLSConfig)
+	publisher.WithAuth(SMTP_AUTH_PLAIN, "jane", "secret")
+
+	suite.Nil(publisher.Send([]byte("report"), "report.xlsx"))
+	mail := server.lastMail()
+	suite.True(mail.tls)
+	suite.Equal("jane:secret", mail.auth)
+	suite.Equal("sender@example.com", mail.from)
+	suite.Equal([]string{"receiver@example.com"}, mail.to)
+	suite.Contains(mail.data, "Subject: Time Tracking Report")
+	suite.Contains(mail.data, "<p>Your report</p>")
+	suite.Contains(mail.data, base64.StdEncoding.EncodeToString([]byte("report")))
+}
+
+func (suite *SMTPPublisherTestSuite) TestSendWithImplicitTLS() {
+
+	server := newFakeSMTPServer(suite.T(), suite.serverTLSConfig, true)
+	publisher := suite.publisherForTest(server)
+	publisher.WithSecurity(SMTP_TLS)
+	publisher.WithTLSConfig(suite.clientTLSConfig)
+	publisher.WithAuth(SMTP_AUTH_LOGIN, "jane", "secret")
+
+	suite.Nil(publisher.Send([]byte("report"), "report.xlsx"))
+	mail := server.lastMail()
+	suite.True(mail.tls)
+	suite.Equal("jane:secret", mail.auth)
+}
+
+func (suite *SMTPPublisherTestSuite) TestSendWithoutEncryption() {
+
+	server := newFakeSMTPServer(suite.T(), nil, false)
+	publisher := suite.publisherForTest(server)
+	publisher.WithSecurity(SMTP_NONE)
+
+	publisher.WithCC("cc@example.com")
+	publisher.WithBCC("bcc@example.com")
+
+	suite.Nil(publisher.Send([]byte("report"), "report.pdf"))
+	mail := server.lastMail()
+	suite.False(mail.tls)
+	suite.Equal("", mail.auth)
+	suite.Equal([]string{"receiver@example.com", "cc@example.com", "bcc@example.com"}, mail.to)
+	suite.Contains(mail.data, "Content-Type: application/pdf")
+	suite.NotContains(mail.data, "bcc@example.com")
+}
+
+func (suite *SMTPPublisherTestSuite) TestSendReportLink() {
+
+	server := newFakeSMTPServer(suite.T(), nil, false)
+	publisher := suite.publisherForTest(server)
+	publisher.WithSecurity(SMTP_NONE)
+	publisher.WithReportLink(&reportLinkForTest{url: "https://example.com/report.xlsx"}, 3)
+
+	suite.Nil(publisher.Send([]byte("large report"), "report.xlsx"))
+	mail := server.lastMail()
+	suite.Contains(mail.data, "https://example.com/report.xlsx")
+	suite.NotContains(mail.data, base64.StdEncoding.EncodeToString([]byte("large report")))
+	suite.NotContains(mail.data, "filename=report.xlsx")
+
+	suite.Nil(publisher.Send([]byte("rep"), "report.xlsx"))
+	mail = server.lastMail()
+	suite.NotContains(mail.data, "https://example.com/report.xlsx")
+	suite.Contains(mail.data, base64.StdEncoding.EncodeToString([]byte("rep")))
+}
+
+func (suite *SMTPPublisherTestSuite) TestSendErrors() {
+
+	server := newFakeSMTPServer(suite.T(), nil, false)
+	publisher := suite.publisherForTest(server)
+	suite.NotNil(publisher.Send([]byte("report"), "report.xlsx"))
+
+	publisher.WithSecurity(SMTP_NONE)
+	publisher.WithAuth("CRAM-MD5", "jane", "secret")
+	suite.NotNil(publisher.Send([]byte("report"), "report.xlsx"))
+
+	publisher.WithAuth(SMTP_AUTH_LOGIN, "jane", "wrong")
+	suite.NotNil(publisher.Send([]byte("report"), "report.xlsx"))
+
+	publisher.WithSecurity("ssl")
+	suite.NotNil(publisher.Send([]byte("report"), "report.xlsx"))
+}
+
+func (suite *SMTPPublisherTestSuite) publisherForTest(server *fakeSMTPServer) *SMTPPublisher {
+	publisher := NewSMTPPublisher("127.0.0.1", server.port(), "sender@example.com", "receiver@example.com",
+		"Time Tracking Report", "<html><p>Your report</p></html>")
+	publisher.WithTimeout(5 * time.Second)
+	return publisher
+}
+
+// FakeSMTPMail is a mail received by a fake SMTP server.
+type fakeSMTPMail struct {
+	tls  bool
+	auth string
+	from string
+	to   []string
+	data string
+}
+
+// FakeSMTPServer is a minimal SMTP server for tests, supporting STARTTLS, implicit TLS and PLAIN/LOGIN authentication.
+// Accepted credentials are jane/secret.
+type fakeSMTPServer struct {
+	listener  net.Listener
+	tlsConfig *tls.Config
+	mails     []fakeSMTPMail
+	lock      sync.Mutex
+}
+
+// NewFakeSMTPServer starts a fake SMTP server on a random local port. Listener uses implicit TLS if requested,
+// otherwise STARTTLS is offered if there's a TLS config.
+func newFakeSMTPServer(t *testing.T, tlsConfig *tls.Config, implicitTLS bool) *fakeSMTPServer {
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if implicitTLS {
+		listener = tls.NewListener(listener, tlsConfig)
+	}
+	server := &fakeSMTPServer{listener: listener, tlsConfig: tlsConfig}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.handle(conn, implicitTLS)
+		}
+	}()
+	return server
+}
+
+func (server *fakeSMTPServer) port() int {
+	return server.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (server *fakeSMTPServer) lastMail() fakeSMTPMail {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	if len(server.mails) == 0 {
+		return fakeSMTPMail{}
+	}
+	return server.mails[len(server.mails)-1]
+}
+
+func (server *fakeSMTPServer) handle(conn net.Conn, implicitTLS bool) {
+
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(10 * time.Second))
+	mail := fakeSMTPMail{tls: implicitTLS}
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+	readLine := func() (string, bool) {
+		line, err := reader.ReadString('\n')
+		return strings.TrimRight(line, "\r\n"), err == nil
+	}
+	checkCredentials := func(username, password string) {
+		if username == "jane" && password == "secret" {
+			mail.auth = username + ":" + password
+			reply("235 Authentication successful")
+		} else {
+			reply("535 Authentication failed")
+		}
+	}
+
+	reply("220 fake.smtp ESMTP")
+	for {
+		line, ok := readLine()
+		if !ok {
+			return
+		}
+		command := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(command, "EHLO"):
+			reply("250-fake.smtp")
+			if server.tlsConfig != nil && !mail.tls {
+				reply("250-STARTTLS")
+			}
+			reply("250 AUTH PLAIN LOGIN")
+		case command == "STARTTLS":
+			reply("220 Ready to start TLS")
+			tlsConn := tls.Server(conn, server.tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn, reader, mail.tls = tlsConn, bufio.NewReader(tlsConn), true
+		case strings.HasPrefix(command, "AUTH PLAIN"):
+			credentials, _ := base64.StdEncoding.DecodeString(strings.TrimSpace(line[len("AUTH PLAIN"):]))
+			fields := strings.Split(string(credentials), "\x00")
+			if len(fields) != 3 {
+				reply("501 Invalid credentials")
+				continue
+			}
+			checkCredentials(fields[1], fields[2])
+		case command == "AUTH LOGIN":
+			reply("334 " + base64.StdEncoding.EncodeToString([]byte("Username:")))
+			encodedUsername, _ := readLine()
+			reply("334 " + base64.StdEncoding.EncodeToString([]byte("Password:")))
+			encodedPassword, _ := readLine()
+			username, _ := base64.StdEncoding.DecodeString(encodedUsername)
+			password, _ := base64.StdEncoding.DecodeString(encodedPassword)
+			checkCredentials(string(username), string(password))
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			mail.from = strings.Trim(line[len("MAIL FROM:"):], "<> ")
+			reply("250 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			mail.to = append(mail.to, strings.Trim(line[len("RCPT TO:"):], "<> "))
+			reply("250 OK")
+		case command == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			data := []string{}
+			for {
+				dataLine, ok := readLine()
+				if !ok {
+					return
+				}
+				if dataLine == "." {
+					break
+				}
+				data = append(data, strings.TrimPrefix(dataLine, "."))
+			}
+			mail.data = strings.Join(data, "\r\n")
+			server.lock.Lock()
+			server.mails = append(server.mails, mail)
+			server.lock.Unlock()
+			reply("250 OK")
+		case command == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+// TlsConfigsForTest generates a self-signed certificate for 127.0.0.1 and returns a server config using this
+// certificate and a client config trusting it.
+func tlsConfigsForTest(t *testing.T) (*tls.Config, *tls.Config) {
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-1 * time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(certDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPool := x509.NewCertPool()
+	certPool.AddCert(cert)
+	serverConfig := &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{certDER}, PrivateKey: key}}}
+	clientConfig := &tls.Config{RootCAs: certPool, ServerName: "127.0.0.1"}
+	return serverConfig, clientConfig
+}
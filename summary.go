@@ -0,0 +1,82 @@
+package timetracker
+
+import "time"
+
+// ReportSummary contains key figures of a monthly report.
+type ReportSummary struct {
+
+	// Year this summary belongs to.
+	Year int
+
+	// Month this summary belongs to.
+	Month int
+
+	// TotalWorkingTine is the entire working time of a month.
+	TotalWorkingTime time.Duration
+
+	// TargetWorkingTime is the expected working time of a month.
+	TargetWorkingTime time.Duration
+
+	// Overtime is the difference between total and target working time.
+	Overtime time.Duration
+
+	// AbsenceDays is the number of days, Monday to Friday, for each absence type, e.g. illness or vacation.
+	// Business trips and trainings are working days and not counted as absence. Public holidays are not counted, too,
+	// if they've been passed to SummarizeMonthlyReportWithHolidays.
+	AbsenceDays map[RecordType]int
+
+	// WorkLocationDays is the number of days per work location.
+	WorkLocationDays map[WorkLocation]int
+
+	// Violations is a list of all violated compliance rules.
+	Violations []ComplianceViolation
+}
+
+// SummarizeMonthlyReport generates a summary for given report.
+// Use SummarizeMonthlyReportWithHolidays to exclude public holidays from absence days.
+func SummarizeMonthlyReport(report *MonthlyReport) ReportSummary {
+	return SummarizeMonthlyReportWithHolidays(report, []Holiday{})
+}
+
+// SummarizeMonthlyReportWithHolidays generates a summary for given report. Public holidays within a span
+// of illness or vacation are not counted as absence days, because they don't consume e.g. vacation days.
+func SummarizeMonthlyReportWithHolidays(report *MonthlyReport, holidays []Holiday) ReportSummary {
+
+	summary := ReportSummary{
+		Year:              report.Year,
+		Month:             report.Month,
+		TotalWorkingTime:  report.TotalWorkingTime,
+		TargetWorkingTime: report.TargetWorkingTime,
+		Overtime:          report.Overtime,
+		AbsenceDays:       make(map[RecordType]int),
+		WorkLocationDays:  make(map[WorkLocation]int),
+		Violations:        CheckCompliance(report),
+	}
+	for workLocation, days := range report.WorkLocationDays {
+		summary.WorkLocationDays[workLocation] = days
+	}
+	holidayMap := asHolidayMap(holidays)
+	for _, day := range report.Days {
+		if _, isHoliday := holidayMap[day.Date]; isHoliday {
+			continue
+		}
+		if !isWorkingType(day.Type) && day.Type != WEEKEND && !isWeekend(day.Date.AsTime()) {
+			summary.AbsenceDays[day.Type]++
+		}
+	}
+	return summary
+}
+
+// TotalAbsenceDays returns number of absence days of all types.
+func (summary ReportSummary) TotalAbsenceDays() int {
+	totalDays := 0
+	for _, days := range summary.AbsenceDays {
+		totalDays += days
+	}
+	return totalDays
+}
+
+// IsCompliant returns true if there's no violation of compliance rules.
+func (summary ReportSummary) IsCompliant() bool {
+	return len(summary.Violations) == 0
+}
@@ -0,0 +1,75 @@
+package timetracker
+
+import "time"
+
+// NewTeamReportCalculator returns a calculator to generate reports for all given team members.
+// Passed time tracker is used to list time tracking records, use a PersonTimeTracker for persons with multiple devices.
+func NewTeamReportCalculator(tracker TimeTracker, members []TeamMember) *TeamReportCalculator {
+	return &TeamReportCalculator{
+		tracker:  tracker,
+		members:  members,
+		holidays: []Holiday{},
+	}
+}
+
+// TeamReportCalculator creates monthly reports for a list of team members.
+type TeamReportCalculator struct {
+
+	// Tracker is used to list time tracking records of each team member.
+	tracker TimeTracker
+
+	// Members is a list of all persons or devices a team report should be generated for.
+	members []TeamMember
+
+	// Holidays is a list of public holidays, applied to report calculation of each team member.
+	holidays []Holiday
+}
+
+// WithHolidays will assign given list of public holidays for report calculation.
+func (calculator *TeamReportCalculator) WithHolidays(holidays []Holiday) {
+	calculator.holidays = holidays
+}
+
+// TeamReport generates monthly reports for all team members for given year and month.
+// Latest type of each team member is taken from last day of previous month.
+func (calculator *TeamReportCalculator) TeamReport(year, month int) (*TeamReport, error) {
+
+	report := &TeamReport{
+		Year:    year,
+		Month:   month,
+		Members: []TeamMemberReport{},
+	}
+
+	startOfMonth := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	startOfPreviousMonth := startOfMonth.AddDate(0, -1, 0)
+	endOfMonth := startOfMonth.AddDate(0, 1, 0).Add(-1 * time.Second)
+	for _, member := range calculator.members {
+
+		records, err := calculator.tracker.ListRecords(member.Id, startOfPreviousMonth, endOfMonth)
+		if err != nil {
+			return nil, err
+		}
+
+		reportCalculator := NewReportCalulator(records, member.Locale)
+		reportCalculator.WithHolidays(calculator.holidays)
+		previousReport, err := reportCalculator.MonthlyReport(startOfPreviousMonth.Year(), int(startOfPreviousMonth.Month()), WORKDAY)
+		if err != nil {
+			return nil, err
+		}
+		latestType := WORKDAY
+		if len(previousReport.Days) > 0 {
+			latestType = previousReport.Days[len(previousReport.Days)-1].Type
+		}
+
+		monthlyReport, err := reportCalculator.MonthlyReport(year, month, latestType)
+		if err != nil {
+			return nil, err
+		}
+		report.Members = append(report.Members, TeamMemberReport{
+			Member:  member,
+			Report:  monthlyReport,
+			Summary: SummarizeMonthlyReportWithHolidays(monthlyReport, calculator.holidays),
+		})
+	}
+	return report, nil
+}
@@ -0,0 +1,67 @@
+package timetracker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TeamReportTestSuite struct {
+	suite.Suite
+}
+
+func TestTeamReportTestSuite(t *testing.T) {
+	suite.Run(t, new(TeamReportTestSuite))
+}
+
+func (suite *TeamReportTestSuite) TestTeamReport() {
+
+	calculator := NewTeamReportCalculator(suite.trackerForTest(), teamMembersForTest())
+	calculator.WithHolidays([]Holiday{Holiday{Date: Date{Year: 2022, Month: 2, Day: 2}, Description: "Test Holiday"}})
+	report, err := calculator.TeamReport(2022, 2)
+	suite.Nil(err)
+	suite.Equal(2022, report.Year)
+	suite.Equal(2, report.Month)
+	suite.Len(report.Members, 2)
+
+	report1 := report.Members[0]
+	suite.Equal("Jane Doe", report1.Member.Name)
+	suite.Len(report1.Report.Days, 2)
+	suite.Equal(18*time.Hour+30*time.Minute, report1.Summary.TotalWorkingTime)
+	suite.Equal(8*time.Hour, report1.Summary.TargetWorkingTime)
+	suite.Len(report1.Summary.Violations, 1)
+
+	report2 := report.Members[1]
+	suite.Equal("John Doe", report2.Member.Name)
+	suite.Equal(VACATION, report2.Report.Days[0].Type)
+	// Holiday at 2022-02-02 doesn't consume a vacation day.
+	suite.Equal(1, report2.Summary.TotalAbsenceDays())
+	suite.True(report2.Summary.IsCompliant())
+
+	_, err = NewTeamReportCalculator(suite.trackerForTest(), []TeamMember{TeamMember{Id: "Person04"}}).TeamReport(2022, 2)
+	suite.NotNil(err)
+}
+
+func (suite *TeamReportTestSuite) trackerForTest() TimeTracker {
+	repo := NewLocaLRepository()
+	repo.Captured("Device01", WORKDAY, asTime("2022-02-01T08:00:00"))
+	repo.Captured("Device02", WORKDAY, asTime("2022-02-01T19:00:00"))
+	repo.Captured("Device01", WORKDAY, asTime("2022-02-02T07:00:00"))
+	repo.Captured("Device02", WORKDAY, asTime("2022-02-02T16:00:00"))
+	repo.Captured("Device03", VACATION, asTime("2022-01-31T08:00:00"))
+	repo.Captured("Device03", WORKDAY, asTime("2022-02-03T08:00:00"))
+	repo.Captured("Device03", WORKDAY, asTime("2022-02-03T16:00:00"))
+	registry := personRegistryForTest()
+	registry.Add(Person{Id: "Person03", Name: "Max Mustermann", DeviceIds: []string{"Device03"}})
+	return NewPersonTimeTracker(repo, registry)
+}
+
+func teamMembersForTest() []TeamMember {
+	locale := localeForTest()
+	locale.WorkSchedule = workScheduleForTest()
+	return []TeamMember{
+		TeamMember{Id: "Person01", Name: "Jane Doe", Locale: locale},
+		TeamMember{Id: "Person03", Name: "John Doe", Locale: locale},
+	}
+}
@@ -0,0 +1,206 @@
+package timetracker
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	log "github.com/tommzn/go-log"
+)
+
+// NewTemplateReportFormatter returns a formatter which renders reports with given template file.
+// Templates with extension .html or .htm, optional followed by .tmpl, are parsed with html/template
+// all other with text/template. Extension of output files is taken from template file name, e.g. ".md" for "report.md.tmpl".
+func NewTemplateReportFormatter(templateFile string, logger log.Logger) (*TemplateReportFormatter, error) {
+
+	formatter := &TemplateReportFormatter{
+		reportFormat:  newReportFormat(logger),
+		fileExtension: outputExtensionOf(templateFile),
+	}
+
+	content, err := os.ReadFile(templateFile)
+	if err != nil {
+		return nil, err
+	}
+	name := filepath.Base(templateFile)
+	if formatter.fileExtension == ".html" || formatter.fileExtension == ".htm" {
+		formatter.template, err = htmltemplate.New(name).Funcs(formatter.templateFuncs()).Parse(string(content))
+	} else {
+		formatter.template, err = template.New(name).Funcs(formatter.templateFuncs()).Parse(string(content))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return formatter, nil
+}
+
+// ReportTemplate is a parsed text or html template.
+type reportTemplate interface {
+	Execute(io.Writer, any) error
+}
+
+// TemplateReportFormatter renders reports with a user defined text/template or html/template.
+type TemplateReportFormatter struct {
+
+	// ReportFormat contains date/time formats, timezone and holidays.
+	reportFormat
+
+	// Template used to render reports.
+	template reportTemplate
+
+	// FileExtension of generated output files.
+	fileExtension string
+}
+
+// TemplateReportData is passed to a template to render a report.
+type TemplateReportData struct {
+
+	// Report is the monthly report which should be rendered.
+	Report *MonthlyReport
+
+	// Locale of current report.
+	Locale Locale
+
+	// Holidays is a list of all holidays in report month.
+	Holidays []Holiday
+
+	// Days contains all calendar days of report month with formatted values.
+	Days []TemplateDay
+
+	// Summary contains key figures and compliance violations of a report.
+	Summary ReportSummary
+}
+
+// TemplateDay is a single calendar day with formatted values.
+type TemplateDay struct {
+
+	// Day of a report. Days without time tracking records are empty working days.
+	Day
+
+	// FormattedDate is the date of a day in date format of report locale.
+	FormattedDate string
+
+	// Weekday is the name of a day of the week in language of report locale, e.g. Monday.
+	Weekday string
+
+	// Start and End are formatted timestamps of first and last event of a day.
+	Start, End string
+
+	// WorkingTime and BreakTime in format HH:MM.
+	WorkingTime, BreakTime string
+
+	// Comment is the description of a holiday or a label for days of illness, vacation, etc.
+	Comment string
+
+	// IsWeekend is true for Saturday and Sunday.
+	IsWeekend bool
+
+	// IsHoliday is true for public holidays.
+	IsHoliday bool
+}
+
+// WithFileExtension overwrites extension of output files, e.g. ".tex".
+func (formatter *TemplateReportFormatter) WithFileExtension(fileExtension string) {
+	formatter.fileExtension = fileExtension
+}
+
+// FileExtension returns extension of output files, taken from template file name.
+func (formatter *TemplateReportFormatter) FileExtension() string {
+	return formatter.fileExtension
+}
+
+// WriteMonthlyReportToFile will generate a report outout an writes it to given file.
+func (formatter *TemplateReportFormatter) WriteMonthlyReportToFile(report *MonthlyReport, filename string) error {
+
+	buf, err := formatter.WriteMonthlyReportToBuffer(report)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, buf.Bytes(), 0644)
+}
+
+// WriteMonthlyReportToBuffer returns a buffer for gemerated report output.
+func (formatter *TemplateReportFormatter) WriteMonthlyReportToBuffer(report *MonthlyReport) (*bytes.Buffer, error) {
+
+	buf := new(bytes.Buffer)
+	if err := formatter.template.Execute(buf, formatter.templateData(report)); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// TemplateData creates all values passed to a template for given report.
+func (formatter *TemplateReportFormatter) templateData(report *MonthlyReport) TemplateReportData {
+
+	formatter.applyLocale(report)
+	data := TemplateReportData{
+		Report:   report,
+		Locale:   report.Location,
+		Holidays: []Holiday{},
+		Days:     []TemplateDay{},
+		Summary:  formatter.summarize(report),
+	}
+	forEachDayOfMonth(report, func(day Day) {
+		templateDay := TemplateDay{
+			Day:           day,
+			FormattedDate: formatter.formatDate(day.Date),
+			Weekday:       formatter.messages.weekday(day.Date.AsTime().Weekday()),
+			WorkingTime:   formatDuration(day.WorkingTime),
+			BreakTime:     formatDuration(day.BreakTime),
+			Comment:       formatter.commentOf(day),
+			IsWeekend:     isWeekend(day.Date.AsTime()),
+		}
+		if len(day.Events) > 0 {
+			templateDay.Start = formatter.formatTime(day.Events[0].Timestamp)
+		}
+		if len(day.Events) > 1 {
+			templateDay.End = formatter.formatTime(day.Events[len(day.Events)-1].Timestamp)
+		}
+		if holiday, ok := formatter.holidays[day.Date]; ok {
+			templateDay.IsHoliday = true
+			data.Holidays = append(data.Holidays, holiday)
+		}
+		data.Days = append(data.Days, templateDay)
+	})
+	return data
+}
+
+// TemplateFuncs returns helper functions available in templates.
+func (formatter *TemplateReportFormatter) templateFuncs() map[string]any {
+	return map[string]any{
+		"formatDuration": formatDuration,
+		"decimalHours": func(d time.Duration) string {
+			return fmt.Sprintf("%.2f", d.Round(time.Minute).Hours())
+		},
+		"minutes": func(d time.Duration) int64 {
+			return int64(d.Round(time.Minute).Minutes())
+		},
+		"formatDate": formatter.formatDate,
+		"formatTime": formatter.formatTime,
+		"weekday": func(date Date) string {
+			return formatter.messages.weekday(date.AsTime().Weekday())
+		},
+		"label": func(key string) string {
+			return formatter.message(messageKey(key))
+		},
+	}
+}
+
+// OutputExtensionOf returns extension of output files for given template file.
+// Template extensions .tmpl, .tpl or .gotmpl are removed, e.g. ".md" for "report.md.tmpl".
+func outputExtensionOf(templateFile string) string {
+	name := filepath.Base(templateFile)
+	for _, templateExtension := range []string{".tmpl", ".tpl", ".gotmpl"} {
+		name = strings.TrimSuffix(name, templateExtension)
+	}
+	if extension := filepath.Ext(name); extension != "" {
+		return extension
+	}
+	return ".txt"
+}
@@ -0,0 +1,71 @@
+package timetracker
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TemplateReportFormatterTestSuite struct {
+	suite.Suite
+}
+
+func TestTemplateReportFormatterTestSuite(t *testing.T) {
+	suite.Run(t, new(TemplateReportFormatterTestSuite))
+}
+
+func (suite *TemplateReportFormatterTestSuite) TestMarkdownTemplate() {
+
+	formatter, err := NewTemplateReportFormatter("fixtures/templates/report.md.tmpl", loggerForTest())
+	suite.Nil(err)
+	suite.Equal(".md", formatter.FileExtension())
+	formatter.WithHolidays([]Holiday{Holiday{Date: Date{Year: 2022, Month: 1, Day: 6}, Description: "Heilige Drei Könige"}})
+	report := monthlyReportWithAllTypesForTest()
+
+	buf, err := formatter.WriteMonthlyReportToBuffer(report)
+	suite.Nil(err)
+	output := buf.String()
+	suite.True(strings.HasPrefix(output, "# Time Tracking Report 2022-01\n"))
+	suite.Contains(output, "| 01.01.2022 | Samstag | 09:00 | 17:30 | 08:00 | 00:30 |  |")
+	suite.Contains(output, "| 06.01.2022 | Donnerstag |  |  | 00:00 | 00:00 | Heilige Drei Könige |")
+	suite.Contains(output, "Total working time: 17:00 (17.00 hours)")
+
+	filename := suite.T().TempDir() + "/report" + formatter.FileExtension()
+	suite.Nil(formatter.WriteMonthlyReportToFile(report, filename))
+	_, err = os.Stat(filename)
+	suite.Nil(err)
+}
+
+func (suite *TemplateReportFormatterTestSuite) TestHTMLTemplate() {
+
+	formatter, err := NewTemplateReportFormatter("fixtures/templates/report.html.tmpl", loggerForTest())
+	suite.Nil(err)
+	suite.Equal(".html", formatter.FileExtension())
+	formatter.WithHolidays([]Holiday{Holiday{Date: Date{Year: 2022, Month: 1, Day: 6}, Description: "<b>Holiday</b>"}})
+
+	buf, err := formatter.WriteMonthlyReportToBuffer(monthlyReportForTest())
+	suite.Nil(err)
+	suite.Equal("<ul><li>06.01.2022 &lt;b&gt;Holiday&lt;/b&gt;</li></ul>\n", buf.String())
+
+	formatter.WithFileExtension(".htm")
+	suite.Equal(".htm", formatter.FileExtension())
+}
+
+func (suite *TemplateReportFormatterTestSuite) TestInvalidTemplates() {
+
+	_, err := NewTemplateReportFormatter("fixtures/templates/not-existing.tmpl", loggerForTest())
+	suite.NotNil(err)
+
+	filename := suite.T().TempDir() + "/invalid.txt"
+	suite.Nil(os.WriteFile(filename, []byte("{{.Report.Year"), 0644))
+	_, err = NewTemplateReportFormatter(filename, loggerForTest())
+	suite.NotNil(err)
+}
+
+func (suite *TemplateReportFormatterTestSuite) TestOutputExtension() {
+	suite.Equal(".md", outputExtensionOf("templates/report.md.tmpl"))
+	suite.Equal(".tex", outputExtensionOf("report.tex"))
+	suite.Equal(".txt", outputExtensionOf("report.tmpl"))
+}
@@ -31,6 +31,7 @@ func deviceIdForTest() string {
 func localeForTest() Locale {
 	return Locale{
 		Country:    "de",
+		Language:   GERMAN,
 		Timezone:   asStringPointer("Europe/Berlin"),
 		DateFormat: asStringPointer("02.01.2006"),
 		Breaks: map[time.Duration]time.Duration{
@@ -40,3 +41,14 @@ func localeForTest() Locale {
 		DefaultWorkTime: 8*time.Hour + 30*time.Minute,
 	}
 }
+
+// WorkScheduleForTest returns a work schedule with eight hours from Monday to Friday.
+func workScheduleForTest() WorkSchedule {
+	return WorkSchedule{
+		time.Monday:    8 * time.Hour,
+		time.Tuesday:   8 * time.Hour,
+		time.Wednesday: 8 * time.Hour,
+		time.Thursday:  8 * time.Hour,
+		time.Friday:    8 * time.Hour,
+	}
+}
@@ -18,6 +18,39 @@ const (
 
 	// WEEKEND used for non-working days in a week.
 	WEEKEND RecordType = "weekend"
+
+	// BUSINESS_TRIP is used to track days of a business trip.
+	BUSINESS_TRIP RecordType = "business_trip"
+
+	// TRAINING is used to track days of trainings, e.g. workshops or conferences.
+	TRAINING RecordType = "training"
+
+	// COMP_TIME_OFF is used to track compensatory time off. It consumes overtime balance.
+	COMP_TIME_OFF RecordType = "comp_time_off"
+
+	// SPECIAL_LEAVE is used to track special leave, e.g. for a wedding or a move.
+	SPECIAL_LEAVE RecordType = "special_leave"
+
+	// PARENTAL_LEAVE is used to track parental leave.
+	PARENTAL_LEAVE RecordType = "parental_leave"
+)
+
+// WorkLocation defines where work has been done.
+type WorkLocation string
+
+const (
+
+	// HOME is used for work in home office.
+	HOME WorkLocation = "home"
+
+	// OFFICE is used for work on-site at office.
+	OFFICE WorkLocation = "office"
+
+	// REMOTE is used for mobile work, e.g. while travelling.
+	REMOTE WorkLocation = "remote"
+
+	// CLIENT_SITE is used for work on-site at a client.
+	CLIENT_SITE WorkLocation = "client_site"
 )
 
 // MonthlyReport included total amount of work for a month and details about each single day.
@@ -37,6 +70,41 @@ type MonthlyReport struct {
 
 	// TotalWorkingTine is the entire working time of a month.
 	TotalWorkingTime time.Duration
+
+	// TargetWorkingTime is the expected working time of a month, based on work schedule of current locale.
+	TargetWorkingTime time.Duration
+
+	// Overtime is the difference between total and target working time.
+	// A negative value means working time of a month hasn't reached target working time.
+	Overtime time.Duration
+
+	// WorkLocationDays is the number of days per work location.
+	WorkLocationDays map[WorkLocation]int
+}
+
+// YearlyReport is a summary of all monthly reports of a year.
+type YearlyReport struct {
+
+	// Year this report belongs to.
+	Year int
+
+	// Location a report should be generated for.
+	Location Locale
+
+	// Months is a list of monthly reports of a year.
+	Months []MonthlyReport
+
+	// TotalWorkingTine is the entire working time of a year.
+	TotalWorkingTime time.Duration
+
+	// TargetWorkingTime is the expected working time of a year.
+	TargetWorkingTime time.Duration
+
+	// Overtime is the difference between total and target working time of a year.
+	Overtime time.Duration
+
+	// WorkLocationDays is the number of days per work location.
+	WorkLocationDays map[WorkLocation]int
 }
 
 // TimeTrackingReport os a single captured time tracking event.
@@ -56,6 +124,12 @@ type TimeTrackingRecord struct {
 
 	// Estimated time tracking report a used to fill missing events. e.g. workday end if it not has been captured.
 	Estimated bool
+
+	// WorkLocation defines where work has been done. Used for WORKDAY records, only.
+	WorkLocation WorkLocation
+
+	// Project a time tracking record has been captured for. Optional.
+	Project string
 }
 
 // Date is a single calendar day.
@@ -87,6 +161,12 @@ type Day struct {
 	// BreakTime is total time of breaks for a day.
 	BreakTime time.Duration
 
+	// TargetTime is the expected working time for a day.
+	TargetTime time.Duration
+
+	// WorkLocation is the location where most of working time of a day has been spent.
+	WorkLocation WorkLocation
+
 	// Events is a list of captured time tracking events.
 	Events []TimeTrackingRecord
 }
@@ -97,6 +177,10 @@ type Locale struct {
 	// ISO 3166-1 country code.
 	Country string
 
+	// Language used for labels in report outputs, e.g. "de" or "en".
+	// Derived from country if not set, default is english.
+	Language Language
+
 	// Timezone, used to format time in reports.
 	Timezone *string
 
@@ -108,8 +192,15 @@ type Locale struct {
 
 	// Breaks is a map of working durations and breaks which have to be applied for this time.
 	Breaks map[time.Duration]time.Duration
+
+	// WorkSchedule defines target working time, excluding breaks, for each day of a week.
+	// Overtime will not be calculated if there's no work schedule.
+	WorkSchedule WorkSchedule
 }
 
+// WorkSchedule is a map of weekdays and working time expected for this day of a week.
+type WorkSchedule map[time.Weekday]time.Duration
+
 // Holiday is a single, public holiday.
 type Holiday struct {
 
@@ -119,3 +210,84 @@ type Holiday struct {
 	// Description of a public holiday.
 	Description string
 }
+
+// Person is an employee using one or more devices to track working time.
+type Person struct {
+
+	// Id is an unique identifier of a person.
+	Id string
+
+	// Name of a person.
+	Name string
+
+	// DeviceIds is a list of all devices used by a person.
+	DeviceIds []string
+}
+
+// Device is a single device, e.g. an IOT button, used to capture time tracking records.
+type Device struct {
+
+	// Id is an unique identifier of a device.
+	Id string
+
+	// Owner is the name of a person using this device.
+	Owner string
+
+	// EMail address of the owner, used to send reports.
+	EMail string
+
+	// Locale contains settings like country or timezone used to generate reports.
+	Locale Locale
+
+	// Schedule is the target working time per day of a week.
+	// Overwrites work schedule of locale if defined.
+	Schedule WorkSchedule
+
+	// WorkLocation is the default work location for WORKDAY records captured by this device.
+	WorkLocation WorkLocation
+
+	// ActiveFrom is the point in time a device has been taken into service.
+	ActiveFrom *time.Time
+
+	// ActiveUntil is the point in time a device has been taken out of service.
+	ActiveUntil *time.Time
+}
+
+// TeamMember is a single person or device which should be part of a team report.
+type TeamMember struct {
+
+	// Id of a device or a person, used to list time tracking records.
+	Id string
+
+	// Name of a team member.
+	Name string
+
+	// Locale contains settings like country, time zone or work schedule of a team member.
+	Locale Locale
+}
+
+// TeamReport contains monthly reports of all members of a team.
+type TeamReport struct {
+
+	// Year this report belongs to.
+	Year int
+
+	// Month this report has been created for.
+	Month int
+
+	// Members is a list of monthly reports for each team member.
+	Members []TeamMemberReport
+}
+
+// TeamMemberReport is the monthly report of a single team member.
+type TeamMemberReport struct {
+
+	// Member this report has been created for.
+	Member TeamMember
+
+	// Report is the monthly report of a team member.
+	Report *MonthlyReport
+
+	// Summary contains key figures and compliance violations of a monthly report.
+	Summary ReportSummary
+}
@@ -0,0 +1,168 @@
+package timetracker
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"time"
+
+	log "github.com/tommzn/go-log"
+)
+
+// WebhookSignatureHeader contains the HMAC-SHA256 signature of a request body, e.g. sha256=5257a8...
+const WebhookSignatureHeader = "X-Signature-256"
+
+// NewWebhookPublisher returns a publisher which sends reports to given URL.
+// Reports are sent as raw request body with a timeout of 30s and retried with default retry policy.
+func NewWebhookPublisher(url string, logger log.Logger) *WebhookPublisher {
+	return &WebhookPublisher{
+		url:         url,
+		headers:     make(map[string]string),
+		client:      &http.Client{Timeout: 30 * time.Second},
+		retryPolicy: DefaultRetryPolicy(),
+		logger:      logger,
+	}
+}
+
+// WebhookPublisher sends reports with a POST request to a webhook, e.g. of a HR system.
+// Requests failing with a server error, 5xx, or a network error are retried, all other errors are permanent.
+type WebhookPublisher struct {
+
+	// URL of a webhook.
+	url string
+
+	// Headers are added to each request, e.g. an authorization header.
+	headers map[string]string
+
+	// MultipartField is the name of the form field a report is sent in. Report is sent as raw body if empty.
+	multipartField string
+
+	// Secret is used to sign request bodies. Requests are not signed if it's empty.
+	secret []byte
+
+	// Client is used to send requests.
+	client *http.Client
+
+	// RetryPolicy defines retries of failed requests.
+	retryPolicy RetryPolicy
+
+	logger log.Logger
+}
+
+// WithHeader adds a header to all requests.
+func (publisher *WebhookPublisher) WithHeader(name, value string) {
+	publisher.headers[name] = value
+}
+
+// WithMultipart sends reports as multipart/form-data with given field name instead of a raw request body.
+func (publisher *WebhookPublisher) WithMultipart(fieldName string) {
+	publisher.multipartField = fieldName
+}
+
+// WithSignature signs request bodies with HMAC-SHA256 and given secret. Signature is sent in
+// header X-Signature-256 as hex value, prefixed with "sha256=".
+func (publisher *WebhookPublisher) WithSignature(secret string) {
+	publisher.secret = []byte(secret)
+}
+
+// WithTimeout sets timeout of a single request.
+func (publisher *WebhookPublisher) WithTimeout(timeout time.Duration) {
+	publisher.client.Timeout = timeout
+}
+
+// WithRetryPolicy defines how failed requests are retried.
+func (publisher *WebhookPublisher) WithRetryPolicy(retryPolicy RetryPolicy) {
+	publisher.retryPolicy = retryPolicy
+}
+
+// Send posts given report to a webhook.
+func (publisher *WebhookPublisher) Send(content []byte, fileName string) error {
+
+	body, contentType, err := publisher.requestBody(content, fileName)
+	if err != nil {
+		return err
+	}
+	err = publisher.retryPolicy.do(func() error {
+		return publisher.post(body, contentType, fileName)
+	})
+	if err != nil {
+		publisher.logger.Error("Unable to send report to webhook, reason: ", err)
+		return err
+	}
+	publisher.logger.Debug("Report successful sent to webhook: ", fileName)
+	return nil
+}
+
+// RequestBody returns the body of a request and its content type, either raw report content or a multipart form.
+func (publisher *WebhookPublisher) requestBody(content []byte, fileName string) ([]byte, string, error) {
+
+	if publisher.multipartField == "" {
+		return content, contentTypeOf(fileName), nil
+	}
+
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", mime.FormatMediaType("form-data", map[string]string{"name": publisher.multipartField, "filename": fileName}))
+	h.Set("Content-Type", contentTypeOf(fileName))
+	part, err := writer.CreatePart(h)
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err := part.Write(content); err != nil {
+		return nil, "", err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), writer.FormDataContentType(), nil
+}
+
+// Post sends a single request. Returns a permanent error for all responses except server errors.
+func (publisher *WebhookPublisher) post(body []byte, contentType, fileName string) error {
+
+	request, err := http.NewRequest(http.MethodPost, publisher.url, bytes.NewReader(body))
+	if err != nil {
+		return permanent(err)
+	}
+	request.Header.Set("Content-Type", contentType)
+	if publisher.multipartField == "" {
+		request.Header.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
+	}
+	for name, value := range publisher.headers {
+		request.Header.Set(name, value)
+	}
+	if len(publisher.secret) > 0 {
+		request.Header.Set(WebhookSignatureHeader, "sha256="+signatureOf(body, publisher.secret))
+	}
+
+	response, err := publisher.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return nil
+	}
+	err = fmt.Errorf("Webhook returned status %d", response.StatusCode)
+	if response.StatusCode >= 500 {
+		return err
+	}
+	return permanent(err)
+}
+
+// SignatureOf returns the hex encoded HMAC-SHA256 of given content.
+func signatureOf(content, secret []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(content)
+	return hex.EncodeToString(mac.Sum(nil))
+}
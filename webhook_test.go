@@ -0,0 +1,133 @@
+package timetracker
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type WebhookPublisherTestSuite struct {
+	suite.Suite
+}
+
+func TestWebhookPublisherTestSuite(t *testing.T) {
+	suite.Run(t, new(WebhookPublisherTestSuite))
+}
+
+func (suite *WebhookPublisherTestSuite) TestSendRawBody() {
+
+	var request *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	publisher := NewWebhookPublisher(server.URL, loggerForTest())
+	publisher.WithHeader("Authorization", "Bearer token")
+	publisher.WithSignature("secret")
+	suite.Nil(publisher.Send([]byte("date,working time"), "report_202203.csv"))
+
+	suite.Equal(http.MethodPost, request.Method)
+	suite.Equal("date,working time", string(body))
+	suite.Equal("text/csv", request.Header.Get("Content-Type"))
+	suite.Equal("attachment; filename=report_202203.csv", request.Header.Get("Content-Disposition"))
+	suite.Equal("Bearer token", request.Header.Get("Authorization"))
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+	suite.Equal("sha256="+hex.EncodeToString(mac.Sum(nil)), request.Header.Get(WebhookSignatureHeader))
+}
+
+func (suite *WebhookPublisherTestSuite) TestSendMultipart() {
+
+	var fileName, contentType, content, signature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature = r.Header.Get(WebhookSignatureHeader)
+		file, header, err := r.FormFile("report")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fileContent, _ := io.ReadAll(file)
+		fileName, content = header.Filename, string(fileContent)
+		contentType, _, _ = mime.ParseMediaType(header.Header.Get("Content-Type"))
+	}))
+	defer server.Close()
+
+	publisher := NewWebhookPublisher(server.URL, loggerForTest())
+	publisher.WithMultipart("report")
+	suite.Nil(publisher.Send([]byte("%PDF"), "report_202203.pdf"))
+	suite.Equal("report_202203.pdf", fileName)
+	suite.Equal("application/pdf", contentType)
+	suite.Equal("%PDF", content)
+	suite.Equal("", signature)
+}
+
+func (suite *WebhookPublisherTestSuite) TestRetryServerErrors() {
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	publisher := NewWebhookPublisher(server.URL, loggerForTest())
+	publisher.WithRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+	suite.Nil(publisher.Send([]byte("report"), "report.json"))
+	suite.Equal(int32(3), atomic.LoadInt32(&requests))
+
+	atomic.StoreInt32(&requests, 0)
+	publisher.WithRetryPolicy(RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond})
+	err := publisher.Send([]byte("report"), "report.json")
+	suite.NotNil(err)
+	suite.Contains(err.Error(), "503")
+	suite.Equal(int32(2), atomic.LoadInt32(&requests))
+}
+
+func (suite *WebhookPublisherTestSuite) TestNoRetryOnClientErrors() {
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	publisher := NewWebhookPublisher(server.URL, loggerForTest())
+	publisher.WithRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+	suite.NotNil(publisher.Send([]byte("report"), "report.json"))
+	suite.Equal(int32(1), atomic.LoadInt32(&requests))
+}
+
+func (suite *WebhookPublisherTestSuite) TestTimeout() {
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	publisher := NewWebhookPublisher(server.URL, loggerForTest())
+	publisher.WithTimeout(20 * time.Millisecond)
+	publisher.WithRetryPolicy(RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond})
+	suite.NotNil(publisher.Send([]byte("report"), "report.json"))
+	suite.Equal(int32(2), atomic.LoadInt32(&requests))
+
+	publisher = NewWebhookPublisher("://invalid", loggerForTest())
+	suite.NotNil(publisher.Send([]byte("report"), "report.json"))
+}
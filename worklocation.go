@@ -0,0 +1,17 @@
+package timetracker
+
+// WorkLocations is a static mapping of device ids to default work locations.
+type WorkLocations map[string]WorkLocation
+
+// WorkLocationOf returns default work location for given device id.
+func (locations WorkLocations) WorkLocationOf(deviceId string) WorkLocation {
+	return locations[deviceId]
+}
+
+// WithDefaultWorkLocation assigns a default work location to passed WORKDAY record if it hasn't a work location, yet.
+func withDefaultWorkLocation(record TimeTrackingRecord, provider WorkLocationProvider) TimeTrackingRecord {
+	if provider != nil && record.Type == WORKDAY && record.WorkLocation == "" {
+		record.WorkLocation = provider.WorkLocationOf(record.DeviceId)
+	}
+	return record
+}